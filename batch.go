@@ -0,0 +1,179 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+)
+
+// ErrorIndexed is a join of errors tagged with their slice index
+type ErrorIndexed interface {
+	error
+	Unwrap() []error
+	Len() int
+	ErrorAt(i int) error
+	Indexes() []int
+}
+
+// ErrorKeyed is a join of errors tagged with a map key or ID of type K
+type ErrorKeyed[K comparable] interface {
+	error
+	Unwrap() []error
+	Len() int
+	ForKey(key K) error
+	Keys() []K
+}
+
+type withItem struct {
+	key   any
+	cause error
+}
+
+func (w *withItem) Error() string {
+	return fmt.Sprintf("item[%v]: %s", w.key, w.cause.Error())
+}
+
+func (w *withItem) Cause() error {
+	return w.cause
+}
+
+func (w *withItem) Unwrap() error {
+	return w.cause
+}
+
+func (w *withItem) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			if causedByStyle() {
+				io.WriteString(s, causedBy(w))
+				return
+			}
+			fmt.Fprintf(s, "item[%v]: %+v", w.key, w.cause)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, w.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", w.Error())
+	}
+}
+
+// JoinIndexed returns an error that joins the non-nil errs, each tagged with its index in errs
+// If every error in errs is nil, JoinIndexed returns nil.
+func JoinIndexed(errs ...error) ErrorIndexed {
+	e := &withItemJoin{withJoin: &withJoin{}}
+	for i, err := range errs {
+		if err != nil {
+			e.errs = append(e.errs, &withItem{key: i, cause: err})
+		}
+	}
+	if len(e.errs) == 0 {
+		return nil
+	}
+	return e
+}
+
+// JoinKeyed returns an error that joins the non-nil errs, each tagged with its key, ordered by key
+// If every error in errs is nil, JoinKeyed returns nil.
+func JoinKeyed[K comparable](errs map[K]error) ErrorKeyed[K] {
+	e := &withKeyedJoin[K]{withItemJoin: &withItemJoin{withJoin: &withJoin{}}}
+	for k, err := range errs {
+		if err != nil {
+			e.errs = append(e.errs, &withItem{key: k, cause: err})
+		}
+	}
+	if len(e.errs) == 0 {
+		return nil
+	}
+	sort.Slice(e.errs, func(i, j int) bool {
+		return lessKey(e.errs[i].(*withItem).key, e.errs[j].(*withItem).key)
+	})
+	return e
+}
+
+type withItemJoin struct {
+	*withJoin
+}
+
+func (w *withItemJoin) items() []*withItem {
+	errs := w.snapshot()
+	items := make([]*withItem, 0, len(errs))
+	for _, err := range errs {
+		if item, ok := err.(*withItem); ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ErrorAt returns the error at index i of the joined slice, or nil if it was nil
+func (w *withItemJoin) ErrorAt(i int) error {
+	return w.forKey(i)
+}
+
+// Indexes returns the indexes of the non-nil errors
+func (w *withItemJoin) Indexes() []int {
+	items := w.items()
+	indexes := make([]int, 0, len(items))
+	for _, item := range items {
+		if i, ok := item.key.(int); ok {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+func (w *withItemJoin) forKey(key any) error {
+	for _, item := range w.items() {
+		if item.key == key {
+			return item.cause
+		}
+	}
+	return nil
+}
+
+// withKeyedJoin is a withItemJoin whose keys are of type K
+type withKeyedJoin[K comparable] struct {
+	*withItemJoin
+}
+
+func (w *withKeyedJoin[K]) errorOf() error {
+	return w.withItemJoin
+}
+
+// ForKey returns the error for key, or nil if there is none
+func (w *withKeyedJoin[K]) ForKey(key K) error {
+	return w.forKey(key)
+}
+
+// Keys returns the keys of the non-nil errors
+func (w *withKeyedJoin[K]) Keys() []K {
+	items := w.items()
+	keys := make([]K, 0, len(items))
+	for _, item := range items {
+		if k, ok := item.key.(K); ok {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func lessKey(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() == vb.Kind() {
+		switch va.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return va.Int() < vb.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return va.Uint() < vb.Uint()
+		case reflect.Float32, reflect.Float64:
+			return va.Float() < vb.Float()
+		case reflect.String:
+			return va.String() < vb.String()
+		}
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJoinIndexed(t *testing.T) {
+	err1 := errors.New("err1")
+	err3 := NewWithStack("err3")
+	tests := []struct {
+		name        string
+		errs        []error
+		wantErr     string
+		wantIndexes []int
+	}{
+		{
+			"nil",
+			[]error{nil, nil},
+			"",
+			nil,
+		},
+		{
+			"err1, err3",
+			[]error{nil, err1, nil, err3},
+			"item[1]: err1\nitem[3]: err3",
+			[]int{1, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := JoinIndexed(tt.errs...)
+			if tt.wantIndexes == nil {
+				if got != nil {
+					t.Errorf("JoinIndexed() = %v, want nil", got)
+				}
+				return
+			}
+			if got.Error() != tt.wantErr {
+				t.Errorf("JoinIndexed() = %q, want %q", got.Error(), tt.wantErr)
+			}
+			if !reflect.DeepEqual(got.Indexes(), tt.wantIndexes) {
+				t.Errorf("Indexes() = %v, want %v", got.Indexes(), tt.wantIndexes)
+			}
+			for i, err := range tt.errs {
+				if got.ErrorAt(i) != err {
+					t.Errorf("ErrorAt(%d) = %v, want %v", i, got.ErrorAt(i), err)
+				}
+				if err != nil && !Is(got, err) {
+					t.Errorf("Is() = false, want true for %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestJoinKeyed(t *testing.T) {
+	got := JoinKeyed(map[string]error{
+		"user-2": ErrCodeUserNotFound,
+		"user-1": ErrCodeInvalidParams,
+		"user-3": nil,
+	})
+	want := "item[user-1]: [400102030: invalid params]\nitem[user-2]: [500201010: user not found]"
+	if got.Error() != want {
+		t.Errorf("JoinKeyed() = %q, want %q", got.Error(), want)
+	}
+	if !reflect.DeepEqual(got.Keys(), []string{"user-1", "user-2"}) {
+		t.Errorf("Keys() = %v, want %v", got.Keys(), []string{"user-1", "user-2"})
+	}
+	if got.ForKey("user-2") != ErrCodeUserNotFound {
+		t.Errorf("ForKey() = %v, want %v", got.ForKey("user-2"), ErrCodeUserNotFound)
+	}
+	if got.ForKey("user-3") != nil {
+		t.Errorf("ForKey() = %v, want nil", got.ForKey("user-3"))
+	}
+	if got.Len() != 2 {
+		t.Errorf("Len() = %v, want %v", got.Len(), 2)
+	}
+
+	ids := JoinKeyed(map[int64]error{10: New("err10"), 9: New("err9")})
+	if !reflect.DeepEqual(ids.Keys(), []int64{9, 10}) {
+		t.Errorf("Keys() = %v, want %v", ids.Keys(), []int64{9, 10})
+	}
+	if got := ids.ForKey(9); got == nil || got.Error() != "err9" {
+		t.Errorf("ForKey() = %v, want %v", got, "err9")
+	}
+	if got := WithFormatter(Wrap(ids, "batch"), ColonFormatter).Error(); got != "batch: item[9]: err9; item[10]: err10" {
+		t.Errorf("Error() = %q, want %q", got, "batch: item[9]: err9; item[10]: err10")
+	}
+	if JoinKeyed(map[int]error{1: nil}) != nil {
+		t.Errorf("JoinKeyed() want nil")
+	}
+}
+
+func TestJoinIndexed_Format(t *testing.T) {
+	err := JoinIndexed(nil, NewWithStack("err1"))
+	str := fmt.Sprintf("%+v", err)
+	if !strings.HasPrefix(str, "item[1]: err1\ngithub.com/ace-zhaoy/errors.TestJoinIndexed_Format") {
+		t.Errorf("Format() = %v", str)
+	}
+	if LatestMessage(err.Unwrap()[0]).Message() != "err1" {
+		t.Errorf("LatestMessage() = %v, want %v", LatestMessage(err.Unwrap()[0]), "err1")
+	}
+}
@@ -0,0 +1,95 @@
+package errors
+
+import (
+	"sync/atomic"
+)
+
+// JoinCodePolicy decides which ErrorCode LatestCode returns for a join
+type JoinCodePolicy int32
+
+const (
+	// JoinCodeFirst chooses the code of the first error that has one
+	JoinCodeFirst JoinCodePolicy = iota
+	// JoinCodeHighest chooses the code of the most severe error by Severity, the numerically highest code wins a tie
+	JoinCodeHighest
+	// JoinCodeMostFrequent chooses the most frequent code, the first to reach the count wins a tie
+	JoinCodeMostFrequent
+)
+
+var joinCodePolicy int32
+
+// SetJoinCodePolicy sets the policy LatestCode uses for joins, the default is JoinCodeFirst
+func SetJoinCodePolicy(policy JoinCodePolicy) {
+	atomic.StoreInt32(&joinCodePolicy, int32(policy))
+}
+
+// joiner is implemented by joins, including those from the standard library
+type joiner interface {
+	Unwrap() []error
+}
+
+func joinCode(errs []error) ErrorCode {
+	policy := JoinCodePolicy(atomic.LoadInt32(&joinCodePolicy))
+	var (
+		latest   ErrorCode
+		severity SeverityLevel
+		counts   map[int]int
+	)
+	for _, err := range errs {
+		c := LatestCode(err)
+		if c == nil {
+			continue
+		}
+		switch policy {
+		case JoinCodeHighest:
+			l := Severity(err)
+			if latest == nil || l > severity || (l == severity && c.Code() > latest.Code()) {
+				latest, severity = c, l
+			}
+		case JoinCodeMostFrequent:
+			if counts == nil {
+				counts = make(map[int]int)
+			}
+			counts[c.Code()]++
+			if latest == nil || counts[c.Code()] > counts[latest.Code()] {
+				latest = c
+			}
+		default:
+			return c
+		}
+	}
+	return latest
+}
+
+// CodesIn returns the codes in the chain and joins of err, deduplicated in order of appearance
+func CodesIn(err error) []int {
+	var codes []int
+	seen := make(map[int]bool)
+	walk(err, func(err error) {
+		if c, ok := err.(ErrorCode); ok && !seen[c.Code()] {
+			seen[c.Code()] = true
+			codes = append(codes, c.Code())
+		}
+	})
+	return codes
+}
+
+// walk calls fn for err and every error in its chain and joins, outermost first
+func walk(err error, fn func(err error)) {
+	for err != nil {
+		fn(err)
+		switch e := err.(type) {
+		case causer:
+			err = e.Cause()
+		case joiner:
+			for _, err := range e.Unwrap() {
+				walk(err, fn)
+			}
+			return
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		default:
+			return
+		}
+	}
+}
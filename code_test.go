@@ -0,0 +1,90 @@
+package errors
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLatestCode_join(t *testing.T) {
+	err := Join(
+		New("go err"),
+		Wrap(ErrCodeInvalidParams, "err1"),
+		ErrCodeUserNotFound.WrapStack(New("err2")),
+		ErrCodeUserNotFound,
+	)
+	tests := []struct {
+		name   string
+		policy JoinCodePolicy
+		want   int
+	}{
+		{
+			"first",
+			JoinCodeFirst,
+			CodeInvalidParams,
+		},
+		{
+			"highest",
+			JoinCodeHighest,
+			CodeUserNotFound,
+		},
+		{
+			"most frequent",
+			JoinCodeMostFrequent,
+			CodeUserNotFound,
+		},
+	}
+	defer SetJoinCodePolicy(JoinCodeFirst)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetJoinCodePolicy(tt.policy)
+			if got := LatestCode(Wrap(err, "wrap")); got == nil || got.Code() != tt.want {
+				t.Errorf("LatestCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := LatestCode(Join(New("err1"), New("err2"))); got != nil {
+		t.Errorf("LatestCode() = %v, want nil", got)
+	}
+}
+
+func TestLatestMessage_join(t *testing.T) {
+	err := Join(ErrCodeUserNotFound, Wrap(New("err1"), "err2"))
+	if got := LatestMessage(err); got == nil || got.Message() != "user not found" {
+		t.Errorf("LatestMessage() = %v, want %v", got, "user not found")
+	}
+}
+
+func TestCodesIn(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []int
+	}{
+		{
+			"nil",
+			nil,
+			nil,
+		},
+		{
+			"chain",
+			ErrCodeInvalidParams.WrapStack(ErrCodeUserNotFound.Wrapf(New("err"), "err1")),
+			[]int{CodeInvalidParams, CodeUserNotFound},
+		},
+		{
+			"join",
+			Wrap(Join(
+				ErrCodeUserNotFound,
+				JoinIndexed(ErrCodeOrderNotExists, ErrCodeUserNotFound),
+				ErrCodeInvalidParams.WrapStack(ErrCodeOrderNotExists),
+			), "wrap"),
+			[]int{CodeUserNotFound, CodeOrderNotExists, CodeInvalidParams},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CodesIn(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CodesIn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+)
+
+// WrapDefer wraps *err with a message when it is not nil, use with defer
+// If no format is given, the name of the calling function is used as the message
+// The message is formatted lazily, args are retained and must not be modified afterwards.
+//
+//	func A() (err error) {
+//		defer errors.WrapDefer(&err)
+//		// ...
+//	}
+func WrapDefer(err *error, args ...any) {
+	if err == nil || *err == nil {
+		return
+	}
+	st := callers()
+	e := &withMessage{
+		cause: *err,
+	}
+	if len(args) == 0 {
+		e.message = st.funcName()
+	} else {
+		e.format = fmt.Sprint(args[0])
+		e.args = args[1:]
+	}
+	if stackExists(e) {
+		*err = e
+		return
+	}
+	*err = created(&withStack{
+		error: e,
+		stack: st,
+	})
+}
+
+// AppendInto joins e into *err, *err stays first so that Is and LatestCode still see it
+// Both errors are annotated with a stack trace only once
+// If e is nil, *err is left unchanged.
+func AppendInto(err *error, e error) {
+	if err == nil || e == nil {
+		return
+	}
+	appendInto(err, e, callers())
+}
+
+// Close closes c and joins its error into *err, use with defer
+//
+//	func A() (err error) {
+//		f, err := os.Open("a.txt")
+//		if err != nil {
+//			return errors.WithStack(err)
+//		}
+//		defer errors.Close(&err, f)
+//		// ...
+//	}
+func Close(err *error, c io.Closer) {
+	if err == nil || c == nil {
+		return
+	}
+	e := c.Close()
+	if e == nil {
+		return
+	}
+	appendInto(err, e, callers())
+}
+
+func appendInto(err *error, e error, st *stack) {
+	if !stackExists(e) {
+		e = created(&withStack{
+			error: e,
+			stack: st,
+		})
+	}
+	primary := *err
+	if primary == nil {
+		*err = e
+		return
+	}
+	if j, ok := primary.(*withJoin); ok && j.primary {
+		*err = primaryJoin(append(j.Unwrap(), e))
+		return
+	}
+	if !stackExists(primary) {
+		primary = created(&withStack{
+			error: primary,
+			stack: st,
+		})
+	}
+	*err = primaryJoin([]error{primary, e})
+}
+
+// primaryJoin joins errs so that LatestCode sees only the first error, the others are cleanup errors
+func primaryJoin(errs []error) error {
+	return &withJoin{
+		errs:        errs,
+		joinOptions: joinOptions{primary: true},
+	}
+}
@@ -0,0 +1,219 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func wrapDeferNamed(err error) (e error) {
+	defer WrapDefer(&e)
+	return err
+}
+
+func wrapDeferFormat(err error) (e error) {
+	defer WrapDefer(&e, "param: %d", 1)
+	return err
+}
+
+func TestWrapDefer(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func(error) error
+		err     error
+		wantErr string
+	}{
+		{
+			"nil",
+			wrapDeferNamed,
+			nil,
+			"",
+		},
+		{
+			"func name",
+			wrapDeferNamed,
+			errors.New("go err"),
+			"errors.wrapDeferNamed -> {go err}",
+		},
+		{
+			"format",
+			wrapDeferFormat,
+			errors.New("go err"),
+			"param: 1 -> {go err}",
+		},
+		{
+			"ErrCodeUserNotFound",
+			wrapDeferNamed,
+			ErrCodeUserNotFound,
+			"errors.wrapDeferNamed -> {[500201010: user not found]}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fn(tt.err)
+			if tt.err == nil {
+				if err != nil {
+					t.Errorf("WrapDefer() error = %v, want nil", err)
+				}
+				return
+			}
+			if err.Error() != tt.wantErr {
+				t.Errorf("WrapDefer() error = %v, want %v", err, tt.wantErr)
+			}
+			if !Is(err, tt.err) {
+				t.Errorf("WrapDefer() error is not %v", tt.err)
+			}
+			str := fmt.Sprintf("%+v", err)
+			if !strings.Contains(str, "errors.wrapDefer") {
+				t.Errorf("WrapDefer() stack = %v, want calling function", str)
+			}
+		})
+	}
+}
+
+func TestWrapDefer_stackOnce(t *testing.T) {
+	err := wrapDeferNamed(NewWithStack("go err"))
+	if _, ok := err.(*withStack); ok {
+		t.Errorf("WrapDefer() added a second stack")
+	}
+	if !stackExists(err) {
+		t.Errorf("WrapDefer() lost the existing stack")
+	}
+}
+
+type closer struct {
+	err error
+}
+
+func (c closer) Close() error {
+	return c.err
+}
+
+func closeInto(err error, c closer) (e error) {
+	defer Close(&e, c)
+	return err
+}
+
+func TestClose(t *testing.T) {
+	errClose := errors.New("close err")
+	errPrimary := errors.New("go err")
+	tests := []struct {
+		name     string
+		err      error
+		closer   closer
+		wantErr  []error
+		wantCode int
+	}{
+		{
+			"nil, nil",
+			nil,
+			closer{},
+			nil,
+			0,
+		},
+		{
+			"err, nil",
+			ErrCodeUserNotFound,
+			closer{},
+			[]error{ErrCodeUserNotFound},
+			CodeUserNotFound,
+		},
+		{
+			"nil, close err",
+			nil,
+			closer{errClose},
+			[]error{errClose},
+			0,
+		},
+		{
+			"err, close err",
+			ErrCodeUserNotFound,
+			closer{errClose},
+			[]error{ErrCodeUserNotFound, errClose},
+			CodeUserNotFound,
+		},
+		{
+			"no code err, close code err",
+			errPrimary,
+			closer{ErrCodeUserNotFound},
+			[]error{errPrimary, ErrCodeUserNotFound},
+			0,
+		},
+		{
+			"err, close code err",
+			ErrCodeInvalidParams,
+			closer{ErrCodeUserNotFound},
+			[]error{ErrCodeInvalidParams, ErrCodeUserNotFound},
+			CodeInvalidParams,
+		},
+	}
+	defer SetJoinCodePolicy(JoinCodeFirst)
+	SetJoinCodePolicy(JoinCodeHighest)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := closeInto(tt.err, tt.closer)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("Close() error = %v, want nil", err)
+				}
+				return
+			}
+			for _, want := range tt.wantErr {
+				if !Is(err, want) {
+					t.Errorf("Close() error = %v, want %v", err, want)
+				}
+			}
+			if c := LatestCode(err); (c == nil && tt.wantCode != 0) || (c != nil && c.Code() != tt.wantCode) {
+				t.Errorf("LatestCode() = %v, want %v", c, tt.wantCode)
+			}
+			if len(tt.wantErr) == 1 {
+				if err != tt.wantErr[0] && !stackExists(err) {
+					t.Errorf("Close() error should have stack information")
+				}
+				return
+			}
+			errs := err.(*withJoin).Unwrap()
+			if len(errs) != len(tt.wantErr) {
+				t.Fatalf("Close() errors = %v, want %v", errs, tt.wantErr)
+			}
+			for i, e := range errs {
+				if !Is(e, tt.wantErr[i]) {
+					t.Errorf("Close() errors[%d] = %v, want %v", i, e, tt.wantErr[i])
+				}
+				if !stackExists(e) {
+					t.Errorf("Close() errors[%d] should have stack information", i)
+				}
+			}
+		})
+	}
+}
+
+func TestAppendInto(t *testing.T) {
+	err1 := New("err1")
+	err2 := New("err2")
+	err3 := New("err3")
+
+	var err error
+	AppendInto(&err, nil)
+	if err != nil {
+		t.Fatalf("AppendInto() error = %v, want nil", err)
+	}
+	AppendInto(&err, err1)
+	AppendInto(&err, err2)
+	AppendInto(&err, err3)
+
+	errs := err.(*withJoin).Unwrap()
+	want := []error{err1, err2, err3}
+	if len(errs) != len(want) {
+		t.Fatalf("AppendInto() errors = %v, want %v", errs, want)
+	}
+	for i, e := range errs {
+		if !Is(e, want[i]) {
+			t.Errorf("AppendInto() errors[%d] = %v, want %v", i, e, want[i])
+		}
+	}
+	if err.Error() != "err1\nerr2\nerr3" {
+		t.Errorf("AppendInto() error = %q, want %q", err.Error(), "err1\nerr2\nerr3")
+	}
+}
@@ -0,0 +1,46 @@
+// Package errdebug serves the recent errors of package errors, like /debug/pprof
+// It is separate from package errors so that importing errors does not pull in net/http and html/template.
+package errdebug
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+
+	"github.com/ace-zhaoy/errors"
+)
+
+var debugTemplate = template.Must(template.New("errors").Parse(`<!DOCTYPE html>
+<html>
+<head><title>/debug/errors</title></head>
+<body>
+<h1>/debug/errors</h1>
+<p>Recent errors grouped by fingerprint, <a href="?format=json">json</a></p>
+<table>
+<tr><th>Count</th><th>Code</th><th>Message</th><th>Frame</th><th>Last seen</th></tr>
+{{range .}}<tr>
+<td>{{.Count}}</td><td>{{if .Code}}{{.Code}}{{end}}</td>
+<td><details><summary>{{.Message}}</summary><pre>{{(index .Errors 0).Detail}}</pre></details></td>
+<td>{{.Frame}}</td><td>{{.LastSeen.Format "2006-01-02 15:04:05"}}</td>
+</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// Handler returns a handler that shows the recent errors grouped by fingerprint
+// It serves JSON for the query format=json.
+//
+//	http.Handle("/debug/errors", errdebug.Handler())
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		groups := errors.Recent()
+		if r.FormValue("format") == "json" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(groups)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		debugTemplate.Execute(w, groups)
+	})
+}
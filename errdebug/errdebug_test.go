@@ -0,0 +1,33 @@
+package errdebug
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ace-zhaoy/errors"
+)
+
+var errNotFound = errors.NewWithCode(404001, "not found")
+
+func TestHandler(t *testing.T) {
+	errors.SetRecentSize(errors.DefaultRecentSize)
+	errors.Observe(errors.Wrap(errNotFound, "<query>"))
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/errors", nil))
+	body := rec.Body.String()
+	for _, want := range []string{"<details><summary>&lt;query&gt;", "404001", "errdebug.TestHandler"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Handler() = %v, want %v", body, want)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/errors?format=json", nil))
+	var groups []errors.RecentGroup
+	if err := json.Unmarshal(rec.Body.Bytes(), &groups); err != nil || len(groups) != 1 || groups[0].Code != errNotFound.Code() {
+		t.Errorf("Handler() json = %v, %v", rec.Body.String(), err)
+	}
+}
@@ -0,0 +1,35 @@
+// Package errmetrics exposes the error counts of package errors through expvar and
+// the Prometheus text format
+// It is separate from package errors because importing expvar registers /debug/vars on http.DefaultServeMux.
+package errmetrics
+
+import (
+	"expvar"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ace-zhaoy/errors"
+)
+
+// PublishExpvar publishes the error counts as the expvar name
+// Like expvar.Publish, it panics if name is already published.
+func PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return errors.Metrics()
+	}))
+}
+
+// Handler returns a handler that serves the error counts in the Prometheus text format
+// as the counter errors_total with the labels source, code and severity.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		fmt.Fprint(w, "# HELP errors_total Errors created or observed, by code and severity.\n")
+		fmt.Fprint(w, "# TYPE errors_total counter\n")
+		for _, c := range errors.Metrics() {
+			fmt.Fprintf(w, "errors_total{source=%s,code=%s,severity=%s} %d\n",
+				strconv.Quote(c.Source), strconv.Quote(strconv.Itoa(c.Code)), strconv.Quote(c.Severity), c.Count)
+		}
+	})
+}
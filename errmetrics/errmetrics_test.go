@@ -0,0 +1,47 @@
+package errmetrics
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/ace-zhaoy/errors"
+)
+
+func TestHandler(t *testing.T) {
+	errors.ResetMetrics()
+	defer errors.ResetMetrics()
+	errNotFound := errors.NewWithCode(404001, "not found")
+	errors.Observe(errNotFound)
+	errors.Observe(errors.WithSeverity(errNotFound, errors.SeverityCritical))
+	errors.Observe(errors.New("err"))
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	want := `# HELP errors_total Errors created or observed, by code and severity.
+# TYPE errors_total counter
+errors_total{source="observed",code="0",severity="error"} 1
+errors_total{source="observed",code="404001",severity="error"} 1
+errors_total{source="observed",code="404001",severity="critical"} 1
+`
+	if got := rec.Body.String(); got != want {
+		t.Errorf("Handler() = %v, want %v", got, want)
+	}
+}
+
+func TestPublishExpvar(t *testing.T) {
+	errors.ResetMetrics()
+	defer errors.ResetMetrics()
+	errors.Observe(errors.NewWithCode(404001, "not found"))
+
+	if expvar.Get("errors_test") == nil {
+		PublishExpvar("errors_test")
+	}
+	var got []errors.ErrorCount
+	want := errors.Metrics()
+	if err := json.Unmarshal([]byte(expvar.Get("errors_test").String()), &got); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("PublishExpvar() = %v, %v, want %v", got, err, want)
+	}
+}
@@ -4,36 +4,82 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"reflect"
 )
 
 type ErrorMessage interface {
 	Message() string
+	// Template returns the unformatted message, such as "user %d not found"
+	Template() string
+	// Args returns a copy of the arguments of Template, redacted by the redaction policy
+	Args() []any
 	WrapStack(err error) error
 	Wrapf(err error, format string, args ...any) error
 	error
 }
 
+// withMessage is formatted lazily from format and args, message is the literal message without format
+// args are retained, not copied, so they must not be modified after the error is created.
 type withMessage struct {
 	message string
+	format  string
+	args    []any
 	cause   error
 }
 
 func (w *withMessage) Message() string {
-	return w.message
+	return formatMessage(w.message, w.format, w.args, true)
 }
 
-func (w *withMessage) Error() string {
-	if w.cause == nil {
+func (w *withMessage) Template() string {
+	if w.format == "" {
 		return w.message
 	}
-	return fmt.Sprintf("%s -> {%s}", w.Message(), w.Cause().Error())
+	return w.format
+}
+
+func (w *withMessage) Args() []any {
+	return exportArgs(w.args)
 }
 
+func (w *withMessage) Error() string {
+	return renderWith(w, formatter())
+}
+
+// Is reports whether err is a *withMessage with the same unredacted message
+// Formatted messages with the same format and equal args of basic types match without formatting.
 func (w *withMessage) Is(err error) bool {
-	if e, ok := err.(*withMessage); ok {
-		return w.Message() == e.Message()
+	e, ok := err.(*withMessage)
+	if !ok {
+		return false
 	}
-	return false
+	if w == e || (w.format != "" && w.format == e.format && sameArgs(w.args, e.args)) {
+		return true
+	}
+	return w.fullMessage() == e.fullMessage()
+}
+
+// fullMessage returns the unredacted message
+func (w *withMessage) fullMessage() string {
+	return formatMessage(w.message, w.format, w.args, false)
+}
+
+// sameArgs reports whether a and b hold equal args of basic types, such as numbers and strings
+func sameArgs(a, b []any) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		ta, tb := reflect.TypeOf(a[i]), reflect.TypeOf(b[i])
+		if ta == nil || ta != tb || !basicKind(ta.Kind()) || a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func basicKind(k reflect.Kind) bool {
+	return (k >= reflect.Bool && k <= reflect.Complex128) || k == reflect.String
 }
 
 func (w *withMessage) Cause() error {
@@ -45,39 +91,48 @@ func (w *withMessage) Unwrap() error {
 }
 
 // WrapStack If err is nil, WithStack returns nil.
+// The message keeps the args of w, formatted lazily.
 func (w *withMessage) WrapStack(err error) error {
 	if err == nil {
 		return nil
 	}
 	ws := &withStack{
 		error: &withMessage{
-			message: w.Message(),
+			message: w.message,
+			format:  w.format,
+			args:    w.args,
 			cause:   err,
 		},
 	}
 	if !stackExists(ws) {
 		ws.stack = callers()
+		created(ws)
 	}
 	return ws
 }
 
 // Wrapf If err is nil, WithStack returns nil.
+// The message is formatted lazily, args are retained and must not be modified afterwards.
 func (w *withMessage) Wrapf(err error, format string, args ...any) error {
 	if err == nil {
 		return nil
 	}
 	wm := withMessage{
-		message: fmt.Sprintf(format, args...),
-		cause:   err,
+		format: format,
+		args:   args,
+		cause:  err,
 	}
 	ws := &withStack{
 		error: &withMessage{
 			message: w.message,
+			format:  w.format,
+			args:    w.args,
 			cause:   &wm,
 		},
 	}
 	if !stackExists(ws) {
 		ws.stack = callers()
+		created(ws)
 	}
 	return ws
 }
@@ -86,6 +141,10 @@ func (w *withMessage) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if s.Flag('+') {
+			if causedByStyle() {
+				io.WriteString(s, causedBy(w))
+				return
+			}
 			if w.Cause() != nil {
 				fmt.Fprintf(s, "%+v\n", w.Cause())
 			}
@@ -93,8 +152,10 @@ func (w *withMessage) Format(s fmt.State, verb rune) {
 			return
 		}
 		fallthrough
-	case 's', 'q':
+	case 's':
 		io.WriteString(s, w.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", w.Error())
 	}
 }
 
@@ -106,6 +167,8 @@ type ErrorCode interface {
 type withCode struct {
 	code    int
 	message string
+	format  string
+	args    []any
 	cause   error
 }
 
@@ -114,7 +177,18 @@ func (w *withCode) Code() int {
 }
 
 func (w *withCode) Message() string {
-	return w.message
+	return formatMessage(w.message, w.format, w.args, true)
+}
+
+func (w *withCode) Template() string {
+	if w.format == "" {
+		return w.message
+	}
+	return w.format
+}
+
+func (w *withCode) Args() []any {
+	return exportArgs(w.args)
 }
 
 func (w *withCode) Cause() error {
@@ -126,6 +200,7 @@ func (w *withCode) Unwrap() error {
 }
 
 // WrapStack If err is nil, WithStack returns nil.
+// The message keeps the args of w, formatted lazily.
 func (w *withCode) WrapStack(err error) error {
 	if err == nil {
 		return nil
@@ -134,27 +209,34 @@ func (w *withCode) WrapStack(err error) error {
 		error: &withCode{
 			code:    w.code,
 			message: w.message,
+			format:  w.format,
+			args:    w.args,
 			cause:   err,
 		},
 	}
 	if !stackExists(ws) {
 		ws.stack = callers()
+		created(ws)
 	}
 	return ws
 }
 
 // Wrapf If err is nil, WithStack returns nil.
+// The message is formatted lazily, args are retained and must not be modified afterwards.
 func (w *withCode) Wrapf(err error, format string, args ...any) error {
 	if err == nil {
 		return nil
 	}
 	wm := withMessage{
-		message: fmt.Sprintf(format, args...),
-		cause:   err,
+		format: format,
+		args:   args,
+		cause:  err,
 	}
 	wc := withCode{
 		code:    w.code,
 		message: w.message,
+		format:  w.format,
+		args:    w.args,
 		cause:   &wm,
 	}
 	ws := &withStack{
@@ -162,16 +244,13 @@ func (w *withCode) Wrapf(err error, format string, args ...any) error {
 	}
 	if !stackExists(ws) {
 		ws.stack = callers()
+		created(ws)
 	}
 	return ws
 }
 
 func (w *withCode) Error() string {
-	s := fmt.Sprintf("[%d: %s]", w.code, w.message)
-	if w.cause == nil {
-		return s
-	}
-	return fmt.Sprintf("%s -> {%s}", s, w.cause)
+	return renderWith(w, formatter())
 }
 
 func (w *withCode) Is(err error) bool {
@@ -185,15 +264,21 @@ func (w *withCode) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if s.Flag('+') {
+			if causedByStyle() {
+				io.WriteString(s, causedBy(w))
+				return
+			}
 			if w.cause != nil {
 				fmt.Fprintf(s, "%+v\n", w.cause)
 			}
-			fmt.Fprintf(s, "%d: %s", w.code, w.message)
+			fmt.Fprintf(s, "%d: %s", w.code, w.Message())
 			return
 		}
 		fallthrough
-	case 's', 'q':
+	case 's':
 		io.WriteString(s, w.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", w.Error())
 	}
 }
 
@@ -220,6 +305,7 @@ func (w *withStack) Wrap(err error) error {
 	}
 	if !stackExists(err) {
 		e.stack = callers()
+		created(e)
 	}
 
 	return e
@@ -229,6 +315,10 @@ func (w *withStack) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if s.Flag('+') {
+			if causedByStyle() {
+				io.WriteString(s, causedBy(w))
+				return
+			}
 			fmt.Fprintf(s, "%+v", w.error)
 			if w.stack != nil {
 				w.stack.Format(s, verb)
@@ -236,8 +326,10 @@ func (w *withStack) Format(s fmt.State, verb rune) {
 			return
 		}
 		fallthrough
-	case 's', 'q':
+	case 's':
 		io.WriteString(s, w.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", w.Error())
 	}
 }
 
@@ -272,30 +364,37 @@ func New(message string) error {
 	}
 }
 
+// NewWithMessage returns an error with message and no stack
+// The message is formatted lazily, args are retained and must not be modified afterwards.
 func NewWithMessage(format string, args ...any) ErrorMessage {
 	return &withMessage{
-		message: fmt.Sprintf(format, args...),
+		format: format,
+		args:   args,
 	}
 }
 
 // NewWithCode returns an error with code and message
 // NewWithCode no stack
+// The message is formatted lazily, args are retained and must not be modified afterwards.
 func NewWithCode(code int, format string, args ...any) ErrorCode {
 	return &withCode{
-		code:    code,
-		message: fmt.Sprintf(format, args...),
+		code:   code,
+		format: format,
+		args:   args,
 	}
 }
 
 // NewWithStack returns an error without code but with message and stack
+// The message is formatted lazily, args are retained and must not be modified afterwards.
 func NewWithStack(format string, args ...any) error {
 	err := &withMessage{
-		message: fmt.Sprintf(format, args...),
+		format: format,
+		args:   args,
 	}
-	return &withStack{
+	return created(&withStack{
 		error: err,
 		stack: callers(),
-	}
+	})
 }
 
 // WithStack annotates err with a stack trace only once
@@ -309,12 +408,12 @@ func WithStack(err error) error {
 	}
 	if e, ok := err.(*withStack); ok {
 		e.stack = callers()
-		return e
+		return created(e)
 	}
-	return &withStack{
+	return created(&withStack{
 		error: err,
 		stack: callers(),
-	}
+	})
 }
 
 func WithStackForce(err error) error {
@@ -322,46 +421,54 @@ func WithStackForce(err error) error {
 		return nil
 	}
 
-	return &withStack{
+	return created(&withStack{
 		error: err,
 		stack: callers(),
-	}
+	})
 }
 
+// Wrap annotates err with a message and a stack trace only once
+// If err is nil, Wrap returns nil.
+// The message is formatted lazily, args are retained and must not be modified afterwards.
 func Wrap(err error, format string, args ...any) error {
 	if err == nil {
 		return nil
 	}
 	err = &withMessage{
-		message: fmt.Sprintf(format, args...),
-		cause:   err,
+		format: format,
+		args:   args,
+		cause:  err,
 	}
 	if stackExists(err) {
 		return err
 	}
 	if e, ok := err.(*withStack); ok {
 		e.stack = callers()
-		return e
+		return created(e)
 	}
-	return &withStack{
+	return created(&withStack{
 		error: err,
 		stack: callers(),
-	}
+	})
 }
 
+// WrapForce annotates err with a message and a new stack trace
+// If err is nil, WrapForce returns nil.
+// The message is formatted lazily, args are retained and must not be modified afterwards.
 func WrapForce(err error, format string, args ...any) error {
 	if err == nil {
 		return nil
 	}
 	err = &withMessage{
-		message: fmt.Sprintf(format, args...),
-		cause:   err,
+		format: format,
+		args:   args,
+		cause:  err,
 	}
 
-	return &withStack{
+	return created(&withStack{
 		error: err,
 		stack: callers(),
-	}
+	})
 }
 
 func Cause(err error) error {
@@ -389,6 +496,7 @@ func As(err error, target any) bool {
 }
 
 // LatestCode returns the latest ErrorCode
+// For a join, the ErrorCode is chosen from its errors by the JoinCodePolicy.
 func LatestCode(err error) ErrorCode {
 	for err != nil {
 		ex, ok := err.(ErrorCode)
@@ -398,6 +506,13 @@ func LatestCode(err error) ErrorCode {
 
 		e, ok := err.(causer)
 		if !ok {
+			if j, ok := err.(joiner); ok {
+				errs := j.Unwrap()
+				if w, ok := err.(*withJoin); ok && w.primary && len(errs) > 0 {
+					return LatestCode(errs[0])
+				}
+				return joinCode(errs)
+			}
 			break
 		}
 		err = e.Cause()
@@ -406,6 +521,7 @@ func LatestCode(err error) ErrorCode {
 }
 
 // LatestMessage returns the latest ErrorMessage
+// For a join, the ErrorMessage is the first found in its errors.
 func LatestMessage(err error) ErrorMessage {
 	for err != nil {
 		ex, ok := err.(ErrorMessage)
@@ -415,6 +531,13 @@ func LatestMessage(err error) ErrorMessage {
 
 		e, ok := err.(causer)
 		if !ok {
+			if j, ok := err.(joiner); ok {
+				for _, err := range j.Unwrap() {
+					if m := LatestMessage(err); m != nil {
+						return m
+					}
+				}
+			}
 			break
 		}
 		err = e.Cause()
@@ -425,21 +548,27 @@ func LatestMessage(err error) ErrorMessage {
 // Recover Use with Check
 func Recover(f ...func(e error)) {
 	if e := recover(); e != nil {
-		err, ok := e.(error)
-		if !ok {
-			err = &withStack{
-				error: &withMessage{
-					message: fmt.Sprintf("%v", e),
-				},
-				stack: callers(),
-			}
-		}
+		err := panicError(e, callers())
 		for _, v := range f {
 			v(err)
 		}
 	}
 }
 
+// panicError converts a recovered value into an error
+// Values that are not errors are formatted as the message, with st as the stack
+func panicError(v any, st *stack) error {
+	if err, ok := v.(error); ok {
+		return err
+	}
+	return created(&withStack{
+		error: &withMessage{
+			message: fmt.Sprintf("%v", v),
+		},
+		stack: st,
+	})
+}
+
 // Check Use with Recover
 func Check(err error) {
 	if err != nil {
@@ -456,31 +585,34 @@ func CheckWithStack(err error) {
 	}
 	if e, ok := err.(*withStack); ok {
 		e.stack = callers()
-		panic(e)
+		panic(created(e))
 	}
-	panic(&withStack{
+	panic(created(&withStack{
 		error: err,
 		stack: callers(),
-	})
+	}))
 }
 
+// CheckWithWrap panics with err wrapped as Wrap does, use with Recover
+// The message is formatted lazily, args are retained and must not be modified afterwards.
 func CheckWithWrap(err error, format string, args ...any) {
 	if err == nil {
 		return
 	}
 	err = &withMessage{
-		message: fmt.Sprintf(format, args...),
-		cause:   err,
+		format: format,
+		args:   args,
+		cause:  err,
 	}
 	if stackExists(err) {
 		panic(err)
 	}
 	if e, ok := err.(*withStack); ok {
 		e.stack = callers()
-		panic(e)
+		panic(created(e))
 	}
-	panic(&withStack{
+	panic(created(&withStack{
 		error: err,
 		stack: callers(),
-	})
+	}))
 }
@@ -85,8 +85,9 @@ func TestNewWithCode(t *testing.T) {
 				args:   []any{"bbb"},
 			},
 			&withCode{
-				code:    100,
-				message: "aaa: bbb",
+				code:   100,
+				format: "aaa: %s",
+				args:   []any{"bbb"},
 			},
 		},
 		{
@@ -142,7 +143,7 @@ func TestNewWithMessage(t *testing.T) {
 				format: "abc",
 			},
 			&withMessage{
-				message: "abc",
+				format: "abc",
 			},
 		},
 		{
@@ -152,7 +153,8 @@ func TestNewWithMessage(t *testing.T) {
 				args:   []any{123},
 			},
 			&withMessage{
-				message: "abc: 123",
+				format: "abc: %v",
+				args:   []any{123},
 			},
 		},
 	}
@@ -563,3 +565,213 @@ func TestCheckWithWrap(t *testing.T) {
 		})
 	}
 }
+
+func TestFormat(t *testing.T) {
+	numbered := NewWithJoinOptions(JoinNumbered())
+	numbered.Append(New("err1"))
+	numbered.Append(Join(New("err2"), New("err3")))
+	separated := NewWithJoinOptions(JoinSeparator("; "), JoinIndent("\t"))
+	separated.Append(New("err1"))
+	separated.Append(Join(New("err2"), New("err3")))
+
+	tests := []struct {
+		name string
+		err  error
+		want map[string]string
+	}{
+		{
+			"withMessage",
+			New("err1"),
+			map[string]string{
+				"%s":  "err1",
+				"%v":  "err1",
+				"%q":  `"err1"`,
+				"%+v": "err1",
+			},
+		},
+		{
+			"withMessage cause",
+			&withMessage{message: "err2", cause: New("err1")},
+			map[string]string{
+				"%s":  "err2 -> {err1}",
+				"%v":  "err2 -> {err1}",
+				"%q":  `"err2 -> {err1}"`,
+				"%+v": "err1\nerr2",
+			},
+		},
+		{
+			"withCode",
+			NewWithCode(100, "err1"),
+			map[string]string{
+				"%s":  "[100: err1]",
+				"%v":  "[100: err1]",
+				"%q":  `"[100: err1]"`,
+				"%+v": "100: err1",
+			},
+		},
+		{
+			"withStack",
+			WithStack(New("err1")),
+			map[string]string{
+				"%s":  "err1",
+				"%v":  "err1",
+				"%q":  `"err1"`,
+				"%+v": "err1\ngithub.com/ace-zhaoy/errors.TestFormat",
+			},
+		},
+		{
+			"withJoin",
+			Join(New("err1"), New("err2")),
+			map[string]string{
+				"%s":  "err1\nerr2",
+				"%v":  "err1\nerr2",
+				"%q":  `"err1\nerr2"`,
+				"%+v": "err1\nerr2\n",
+			},
+		},
+		{
+			"withJoin numbered",
+			numbered,
+			map[string]string{
+				"%s":  "1. err1\n2. err2\n   err3",
+				"%v":  "1. err1\n2. err2\n   err3",
+				"%q":  `"1. err1\n2. err2\n   err3"`,
+				"%+v": "1. err1\n2. err2\n   err3\n",
+			},
+		},
+		{
+			"withJoin separator",
+			separated,
+			map[string]string{
+				"%s":  "err1; err2\n\terr3",
+				"%v":  "err1; err2\n\terr3",
+				"%q":  `"err1; err2\n\terr3"`,
+				"%+v": "err1\nerr2\n\terr3\n",
+			},
+		},
+		{
+			"withItem",
+			JoinIndexed(New("err1")).Unwrap()[0],
+			map[string]string{
+				"%s":  "item[0]: err1",
+				"%v":  "item[0]: err1",
+				"%q":  `"item[0]: err1"`,
+				"%+v": "item[0]: err1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		for _, format := range []string{"%s", "%v", "%q", "%+v"} {
+			t.Run(tt.name+" "+format, func(t *testing.T) {
+				got := fmt.Sprintf(format, tt.err)
+				got = strings.SplitN(got, "\n\t/", 2)[0]
+				if got != tt.want[format] {
+					t.Errorf("Format(%s) = %q, want %q", format, got, tt.want[format])
+				}
+			})
+		}
+	}
+}
+
+type countStringer struct {
+	count *int
+}
+
+func (s countStringer) String() string {
+	*s.count++
+	return "abc"
+}
+
+func TestErrorMessage_Template(t *testing.T) {
+	count := 0
+	tests := []struct {
+		name         string
+		err          ErrorMessage
+		wantTemplate string
+		wantArgs     []any
+		wantMessage  string
+	}{
+		{
+			"New",
+			New("user 1 not found").(ErrorMessage),
+			"user 1 not found",
+			nil,
+			"user 1 not found",
+		},
+		{
+			"NewWithMessage",
+			NewWithMessage("user %d not found", 1),
+			"user %d not found",
+			[]any{1},
+			"user 1 not found",
+		},
+		{
+			"NewWithCode",
+			NewWithCode(404, "user %d not found", 1),
+			"user %d not found",
+			[]any{1},
+			"user 1 not found",
+		},
+		{
+			"Wrap",
+			LatestMessage(Wrap(New("go err"), "param: %s", countStringer{&count})),
+			"param: %s",
+			[]any{countStringer{&count}},
+			"param: abc",
+		},
+		{
+			"WrapStack",
+			LatestCode(NewWithCode(404, "user %d not found", 1).WrapStack(New("go err"))),
+			"user %d not found",
+			[]any{1},
+			"user 1 not found",
+		},
+	}
+	if count != 0 {
+		t.Errorf("message formatted at construction")
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Template(); got != tt.wantTemplate {
+				t.Errorf("Template() = %v, want %v", got, tt.wantTemplate)
+			}
+			if got := tt.err.Args(); !reflect.DeepEqual(got, tt.wantArgs) {
+				t.Errorf("Args() = %v, want %v", got, tt.wantArgs)
+			}
+			if got := tt.err.Message(); got != tt.wantMessage {
+				t.Errorf("Message() = %v, want %v", got, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func Test_withMessage_Is(t *testing.T) {
+	count := 0
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{"same args", NewWithMessage("user %d", 1), NewWithMessage("user %d", 1), true},
+		{"different args", NewWithMessage("user %d", 1), NewWithMessage("user %d", 2), false},
+		{"formatted literal", NewWithMessage("user %d", 1), New("user 1"), true},
+		{"slice args", NewWithMessage("ids %v", []int{1}), NewWithMessage("ids %v", []int{1}), true},
+		{"stringer args", NewWithMessage("p %s", countStringer{&count}), NewWithMessage("p abc"), true},
+		{"different templates", NewWithMessage("user %d", 1), NewWithMessage("order %d", 1), false},
+		{"literal percent", New("100%"), NewWithMessage("100%"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	err := NewWithMessage("user %d", 1)
+	Is(err, New("user 1"))
+	if !reflect.DeepEqual(err, NewWithMessage("user %d", 1)) {
+		t.Errorf("Is() modified %v", err)
+	}
+}
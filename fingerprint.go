@@ -0,0 +1,95 @@
+package errors
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// DefaultFingerprintFrames is the number of stack frames in a fingerprint
+const DefaultFingerprintFrames = 3
+
+// FingerprintOption configures Fingerprint
+type FingerprintOption func(*fingerprinter)
+
+// FingerprintLines includes line numbers in the frames, the fingerprint changes when code moves
+func FingerprintLines() FingerprintOption {
+	return func(f *fingerprinter) {
+		f.lines = true
+	}
+}
+
+// FingerprintFrames includes the top n frames of the stack, 0 leaves the stack out
+func FingerprintFrames(n int) FingerprintOption {
+	return func(f *fingerprinter) {
+		f.frames = n
+	}
+}
+
+type fingerprinter struct {
+	lines  bool
+	frames int
+}
+
+var pkgPath = reflect.TypeOf(withStack{}).PkgPath()
+
+// foreign reports whether the type of err is defined outside this package
+func foreign(err error) bool {
+	t := reflect.TypeOf(err)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.PkgPath() != pkgPath
+}
+
+// Fingerprint returns a stable hash of the codes and message templates in the chain and joins of err,
+// and of the top frames of the innermost stack
+// Formatted arguments and line numbers are left out, so errors from the same place group together across deploys.
+// Wrappers from other packages are hashed by their type, leaf errors such as io.EOF by their message.
+// If err is nil, Fingerprint returns "".
+func Fingerprint(err error, opts ...FingerprintOption) string {
+	if err == nil {
+		return ""
+	}
+	f := &fingerprinter{frames: DefaultFingerprintFrames}
+	for _, opt := range opts {
+		opt(f)
+	}
+	h := sha1.New()
+	walk(err, func(err error) {
+		switch e := err.(type) {
+		case ErrorCode:
+			fmt.Fprintf(h, "code %d %s\n", e.Code(), e.Template())
+		case ErrorMessage:
+			fmt.Fprintf(h, "message %s\n", e.Template())
+		case causer, joiner, interface{ Unwrap() error }:
+			// wrappers from other packages are hashed by type, their messages repeat the cause
+			if foreign(err) {
+				fmt.Fprintf(h, "wrapper %T\n", err)
+			}
+		default:
+			fmt.Fprintf(h, "error %T %s\n", err, err.Error())
+		}
+	})
+	if st := innerStackOf(err); st != nil && f.frames > 0 {
+		frames := runtime.CallersFrames(*st)
+		for n := 0; n < f.frames; {
+			frame, more := frames.Next()
+			if frame.Function != "" && !strings.HasPrefix(frame.Function, "runtime.") {
+				if f.lines {
+					fmt.Fprintf(h, "frame %s %d\n", frame.Function, frame.Line)
+				} else {
+					fmt.Fprintf(h, "frame %s\n", frame.Function)
+				}
+				n++
+			}
+			if !more {
+				break
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+)
+
+func fingerprintFrom(format string, args ...any) error {
+	return NewWithStack(format, args...)
+}
+
+func TestFingerprint(t *testing.T) {
+	line1 := ErrCodeUserNotFound.Wrapf(New("db"), "user %d", 1)
+	line2 := ErrCodeUserNotFound.Wrapf(New("db"), "user %d", 2)
+	tests := []struct {
+		name  string
+		a     error
+		b     error
+		opts  []FingerprintOption
+		equal bool
+	}{
+		{"args", fingerprintFrom("user %d", 1), fingerprintFrom("user %d", 2), nil, true},
+		{"templates", fingerprintFrom("user %d", 1), fingerprintFrom("order %d", 1), nil, false},
+		{"codes", Wrap(ErrCodeUserNotFound, "query"), Wrap(ErrCodeInvalidParams, "query"), nil, false},
+		{"lines", line1, line2, nil, true},
+		{"with lines", line1, line2, []FingerprintOption{FingerprintLines()}, false},
+		{"frames", fingerprintFrom("err"), NewWithStack("err"), nil, false},
+		{"no frames", fingerprintFrom("err"), NewWithStack("err"), []FingerprintOption{FingerprintFrames(0)}, true},
+		{"std errors", errors.New("a"), errors.New("b"), nil, false},
+		{"std sentinels", Wrap(io.EOF, "read"), Wrap(io.ErrUnexpectedEOF, "read"), []FingerprintOption{FingerprintFrames(0)}, false},
+		{"foreign wrapper ids", fmt.Errorf("user %d: %w", 123, io.EOF), fmt.Errorf("user %d: %w", 456, io.EOF), nil, true},
+		{"foreign wrapper types", fmt.Errorf("a: %w", io.EOF), &os.PathError{Op: "a", Err: io.EOF}, nil, false},
+		{"joins", Join(New("a"), New("b")), Join(New("a"), New("c")), nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := Fingerprint(tt.a, tt.opts...), Fingerprint(tt.b, tt.opts...)
+			if (a == b) != tt.equal {
+				t.Errorf("Fingerprint() = %v, %v, want equal %v", a, b, tt.equal)
+			}
+		})
+	}
+	if Fingerprint(nil) != "" {
+		t.Errorf("Fingerprint() want empty")
+	}
+}
+
+func TestJoinDedup_fingerprint(t *testing.T) {
+	j := NewWithJoinOptions(JoinDedup(DedupFingerprint))
+	for i := 0; i < 3; i++ {
+		j.Append(fingerprintFrom("user %d", i))
+	}
+	j.Append(fingerprintFrom("order %d", 1))
+	if j.Len() != 2 || j.Dropped() != 2 {
+		t.Errorf("Len() = %v, Dropped() = %v, want 2, 2", j.Len(), j.Dropped())
+	}
+
+	j = NewWithJoinOptions(JoinDedup(DedupFingerprint))
+	j.Append(io.EOF)
+	j.Append(io.ErrUnexpectedEOF)
+	if j.Len() != 2 || j.Dropped() != 0 {
+		t.Errorf("Len() = %v, Dropped() = %v, want 2, 0", j.Len(), j.Dropped())
+	}
+}
@@ -0,0 +1,199 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Formatter composes the Error strings of messages, codes and joins
+// cause is nil if there is none, its Error is rendered by the same Formatter.
+type Formatter interface {
+	FormatMessage(message string, cause error) string
+	FormatCode(code int, message string, cause error) string
+	FormatJoin(errs []error) string
+}
+
+var (
+	// DefaultFormatter renders "outer -> {inner -> {root}}", codes as "[code: message]" and joins one per line
+	DefaultFormatter Formatter = defaultFormatter{}
+	// ColonFormatter renders "outer: inner: root" as the Go convention, codes as "message (code)" and joins separated by "; "
+	ColonFormatter Formatter = colonFormatter{}
+	// CompactFormatter renders "outer [inner [root]]", codes as "code:message" and joins as "[err1, err2]"
+	CompactFormatter Formatter = compactFormatter{}
+)
+
+type formatterHolder struct {
+	Formatter
+}
+
+var globalFormatter atomic.Value
+
+// SetFormatter sets the Formatter used by Error, a nil f restores DefaultFormatter
+func SetFormatter(f Formatter) {
+	if f == nil {
+		f = DefaultFormatter
+	}
+	globalFormatter.Store(formatterHolder{f})
+}
+
+func formatter() Formatter {
+	if h, ok := globalFormatter.Load().(formatterHolder); ok {
+		return h.Formatter
+	}
+	return DefaultFormatter
+}
+
+// WithFormatter returns err whose Error renders err and its causes with f instead of the global Formatter
+// If err is nil, WithFormatter returns nil.
+func WithFormatter(err error, f Formatter) error {
+	if err == nil {
+		return nil
+	}
+	return &withFormatter{
+		error:     err,
+		formatter: f,
+	}
+}
+
+type withFormatter struct {
+	error
+	formatter Formatter
+}
+
+func (w *withFormatter) Error() string {
+	return renderWith(w.error, w.formatter)
+}
+
+func (w *withFormatter) Cause() error {
+	return w.error
+}
+
+func (w *withFormatter) Unwrap() error {
+	return w.error
+}
+
+func (w *withFormatter) Format(s fmt.State, verb rune) {
+	formatAnnotation(s, verb, w, w.error, "")
+}
+
+// renderWith renders the Error string of err with f
+func renderWith(err error, f Formatter) string {
+	switch e := err.(type) {
+	case *withMessage:
+		return f.FormatMessage(e.Message(), bind(e.cause, f))
+	case *withCode:
+		return f.FormatCode(e.code, e.Message(), bind(e.cause, f))
+	case *withStack:
+		return renderWith(e.error, f)
+	case *withItem:
+		return fmt.Sprintf("item[%v]: %s", e.key, renderWith(e.cause, f))
+	case *withItemJoin:
+		return renderWith(e.withJoin, f)
+	case *withJoin:
+		errs, dropped := e.view()
+		if len(errs) == 0 {
+			return ""
+		}
+		return e.render(errs, dropped, false, f)
+	case transparent:
+		return renderWith(e.errorOf(), f)
+	}
+	return err.Error()
+}
+
+// transparent is implemented by annotations whose Error is that of another error,
+// such as public messages, hints and secondary errors
+type transparent interface {
+	errorOf() error
+}
+
+// bound is an error whose Error is rendered with formatter
+type bound struct {
+	error
+	formatter Formatter
+}
+
+func (b *bound) Error() string {
+	return renderWith(b.error, b.formatter)
+}
+
+func (b *bound) Unwrap() error {
+	return b.error
+}
+
+func bind(err error, f Formatter) error {
+	if err == nil {
+		return nil
+	}
+	return &bound{
+		error:     err,
+		formatter: f,
+	}
+}
+
+type defaultFormatter struct{}
+
+func (defaultFormatter) FormatMessage(message string, cause error) string {
+	if cause == nil {
+		return message
+	}
+	return fmt.Sprintf("%s -> {%s}", message, cause.Error())
+}
+
+func (defaultFormatter) FormatCode(code int, message string, cause error) string {
+	s := fmt.Sprintf("[%d: %s]", code, message)
+	if cause == nil {
+		return s
+	}
+	return fmt.Sprintf("%s -> {%s}", s, cause.Error())
+}
+
+func (defaultFormatter) FormatJoin(errs []error) string {
+	return joinErrors(errs, "\n")
+}
+
+type colonFormatter struct{}
+
+func (colonFormatter) FormatMessage(message string, cause error) string {
+	if cause == nil {
+		return message
+	}
+	return message + ": " + cause.Error()
+}
+
+func (c colonFormatter) FormatCode(code int, message string, cause error) string {
+	return c.FormatMessage(fmt.Sprintf("%s (%d)", message, code), cause)
+}
+
+func (colonFormatter) FormatJoin(errs []error) string {
+	return joinErrors(errs, "; ")
+}
+
+type compactFormatter struct{}
+
+func (compactFormatter) FormatMessage(message string, cause error) string {
+	if cause == nil {
+		return message
+	}
+	return message + " [" + cause.Error() + "]"
+}
+
+func (c compactFormatter) FormatCode(code int, message string, cause error) string {
+	return c.FormatMessage(fmt.Sprintf("%d:%s", code, message), cause)
+}
+
+func (compactFormatter) FormatJoin(errs []error) string {
+	return "[" + joinErrors(errs, ", ") + "]"
+}
+
+func joinErrors(errs []error, sep string) string {
+	var builder strings.Builder
+	for i, err := range errs {
+		if i > 0 {
+			builder.WriteString(sep)
+		}
+		builder.WriteString(err.Error())
+	}
+	return builder.String()
+}
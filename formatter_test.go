@@ -0,0 +1,95 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFormatter(t *testing.T) {
+	chain := Wrap(ErrCodeInvalidParams.Wrapf(errors.New("record not found"), "id: %d", 1), "service err")
+	join := Join(New("err1"), Wrap(New("err2"), "wrap"))
+	tests := []struct {
+		name      string
+		formatter Formatter
+		wantChain string
+		wantJoin  string
+	}{
+		{
+			"default",
+			DefaultFormatter,
+			"service err -> {[400102030: invalid params] -> {id: 1 -> {record not found}}}",
+			"err1\nwrap -> {err2}",
+		},
+		{
+			"colon",
+			ColonFormatter,
+			"service err: invalid params (400102030): id: 1: record not found",
+			"err1; wrap: err2",
+		},
+		{
+			"compact",
+			CompactFormatter,
+			"service err [400102030:invalid params [id: 1 [record not found]]]",
+			"[err1, wrap [err2]]",
+		},
+	}
+	defer SetFormatter(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetFormatter(tt.formatter)
+			if got := chain.Error(); got != tt.wantChain {
+				t.Errorf("Error() = %q, want %q", got, tt.wantChain)
+			}
+			if got := fmt.Sprintf("%v", join); got != tt.wantJoin {
+				t.Errorf("Error() = %q, want %q", got, tt.wantJoin)
+			}
+
+			SetFormatter(nil)
+			if got := WithFormatter(chain, tt.formatter).Error(); got != tt.wantChain {
+				t.Errorf("WithFormatter() = %q, want %q", got, tt.wantChain)
+			}
+			if got := WithFormatter(join, tt.formatter).Error(); got != tt.wantJoin {
+				t.Errorf("WithFormatter() = %q, want %q", got, tt.wantJoin)
+			}
+		})
+	}
+}
+
+func TestWithFormatter(t *testing.T) {
+	if WithFormatter(nil, ColonFormatter) != nil {
+		t.Errorf("WithFormatter() want nil")
+	}
+	inner := WithFormatter(Wrap(New("root"), "inner"), ColonFormatter)
+	err := Wrap(inner, "outer")
+	if got := err.Error(); got != "outer -> {inner: root}" {
+		t.Errorf("Error() = %q, want %q", got, "outer -> {inner: root}")
+	}
+	if !Is(err, New("root")) {
+		t.Errorf("Is() = false, want true")
+	}
+}
+
+func TestWithFormatter_annotations(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"public", WithPublic(Wrap(New("root"), "mid"), "public"), "top: mid: root"},
+		{"hint", WithHint(Wrap(New("root"), "mid"), "h"), "top: mid: root"},
+		{"detail", WithDetail(Wrap(New("root"), "mid"), "d"), "top: mid: root"},
+		{"secondary", WithSecondary(Wrap(New("root"), "mid"), New("rollback")), "top: mid: root"},
+		{"severity", WithSeverity(Wrap(New("root"), "mid"), SeverityWarning), "top: mid: root"},
+		{"opaque", Opaque(Wrap(New("root"), "mid")), "top: mid: root"},
+		{"boundary", Boundary(ErrCodeInvalidParams, Wrap(New("root"), "mid")), "top: invalid params (400102030)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := WithFormatter(Wrap(tt.err, "top"), ColonFormatter)
+			if got := err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
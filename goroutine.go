@@ -0,0 +1,93 @@
+package errors
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+var fallbackHandler atomic.Value
+
+// SetFallbackHandler sets the process-wide handler for errors from Go that have no handler
+// By default, errors are printed to os.Stderr with stack.
+func SetFallbackHandler(handler func(err error)) {
+	fallbackHandler.Store(handler)
+}
+
+func handleFallback(err error) {
+	if handler, ok := fallbackHandler.Load().(func(error)); ok && handler != nil {
+		handler(err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%+v\n", err)
+}
+
+// Go calls fn in a new goroutine and recovers its panic
+// The panic is passed to handler as an error with the panic stack and the stack where Go was called.
+// If handler is nil, the fallback handler is used.
+func Go(fn func(), handler func(err error)) {
+	origin := callers()
+	go func() {
+		err := safeCall(func() error {
+			fn()
+			return nil
+		})
+		if err == nil {
+			return
+		}
+		err = goroutinePanic(err, origin)
+		if handler == nil {
+			handleFallback(err)
+			return
+		}
+		handler(err)
+	}()
+}
+
+// GoErr calls fn in a new goroutine and sends its error to the returned channel
+// A panic is sent as an error like Go does. The channel is closed after the error is sent.
+func GoErr(fn func() error) <-chan error {
+	origin := callers()
+	ch := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		panicked := true
+		err := safeCall(func() error {
+			err := fn()
+			panicked = false
+			return err
+		})
+		if panicked {
+			err = goroutinePanic(err, origin)
+		}
+		ch <- err
+	}()
+	return ch
+}
+
+// safeCall calls f and recovers its panic as an error with stack
+func safeCall(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			st := callers()
+			err = panicError(r, st)
+			if !stackExists(err) {
+				err = created(&withStack{
+					error: err,
+					stack: st,
+				})
+			}
+		}
+	}()
+	return f()
+}
+
+func goroutinePanic(err error, origin *stack) error {
+	return &withStack{
+		error: &withMessage{
+			message: "panic in goroutine",
+			cause:   err,
+		},
+		stack: origin,
+	}
+}
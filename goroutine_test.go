@@ -0,0 +1,100 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestGo(t *testing.T) {
+	tests := []struct {
+		name      string
+		fn        func()
+		wantErr   string
+		wantStack []string
+	}{
+		{
+			"panic string",
+			func() { panic("test panic") },
+			"panic in goroutine -> {test panic}",
+			[]string{"runtime.gopanic", "TestGo.func1", "TestGo.func"},
+		},
+		{
+			"panic error",
+			func() { panic(ErrCodeUserNotFound) },
+			"panic in goroutine -> {[500201010: user not found]}",
+			[]string{"runtime.gopanic", "TestGo.func2", "TestGo.func"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := make(chan error)
+			Go(tt.fn, func(err error) {
+				ch <- err
+			})
+			err := <-ch
+			if err.Error() != tt.wantErr {
+				t.Errorf("Go() error = %v, want %v", err, tt.wantErr)
+			}
+			str := fmt.Sprintf("%+v", err)
+			for _, want := range tt.wantStack {
+				if !strings.Contains(str, want) {
+					t.Errorf("Go() stack = %v, want containing %v", str, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGo_fallback(t *testing.T) {
+	ch := make(chan error, 1)
+	SetFallbackHandler(func(err error) {
+		ch <- err
+	})
+	defer SetFallbackHandler(nil)
+
+	Go(func() { panic(ErrUserNotFound) }, nil)
+	if err := <-ch; !Is(err, ErrUserNotFound) {
+		t.Errorf("Go() error = %v, want %v", err, ErrUserNotFound)
+	}
+}
+
+func TestGoErr(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func() error
+		wantErr error
+	}{
+		{
+			"nil",
+			func() error { return nil },
+			nil,
+		},
+		{
+			"error",
+			func() error { return ErrCodeUserNotFound },
+			ErrCodeUserNotFound,
+		},
+		{
+			"panic",
+			func() error { panic(ErrCodeInvalidParams) },
+			ErrCodeInvalidParams,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := GoErr(tt.fn)
+			err := <-ch
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("GoErr() error = %v, want nil", err)
+				}
+			} else if !Is(err, tt.wantErr) {
+				t.Errorf("GoErr() error = %v, want %v", err, tt.wantErr)
+			}
+			if _, ok := <-ch; ok {
+				t.Errorf("GoErr() channel is not closed")
+			}
+		})
+	}
+}
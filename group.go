@@ -0,0 +1,82 @@
+package errors
+
+import (
+	"context"
+	"sync"
+)
+
+type GroupMode int
+
+const (
+	// GroupCollectAll runs every function to completion
+	GroupCollectAll GroupMode = iota
+	// GroupFailFast cancels the context on the first error
+	GroupFailFast
+)
+
+// Group runs functions in goroutines and keeps every error, like errgroup.Group
+// A zero Group is a collect-all group without a context and limit.
+type Group struct {
+	mode   GroupMode
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	sem    chan struct{}
+	errs   withJoin
+}
+
+// NewGroup returns a Group and a context derived from ctx
+// The context is canceled when Wait returns, or on the first error in GroupFailFast mode.
+func NewGroup(ctx context.Context, mode GroupMode) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{
+		mode:   mode,
+		cancel: cancel,
+	}, ctx
+}
+
+// SetLimit limits the number of active goroutines to n, a negative n means no limit
+// SetLimit must not be called while goroutines are active.
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	if len(g.sem) != 0 {
+		panic("errors: SetLimit while goroutines are active")
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go calls f in a new goroutine, blocking while the limit is reached
+// A panic in f is recovered as an error with stack, as Recover does.
+func (g *Group) Go(f func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.wg.Add(1)
+	go func() {
+		defer g.done()
+		if err := safeCall(f); err != nil {
+			g.errs.Append(err)
+			if g.mode == GroupFailFast && g.cancel != nil {
+				g.cancel()
+			}
+		}
+	}()
+}
+
+func (g *Group) done() {
+	if g.sem != nil {
+		<-g.sem
+	}
+	g.wg.Done()
+}
+
+// Wait blocks until all functions have returned, then returns a Join of all errors
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	return g.errs.Err()
+}
@@ -0,0 +1,147 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		funcs   []func() error
+		wantLen int
+	}{
+		{
+			"no error",
+			[]func() error{
+				func() error { return nil },
+				func() error { return nil },
+			},
+			0,
+		},
+		{
+			"collect all",
+			[]func() error{
+				func() error { return ErrCodeUserNotFound },
+				func() error { return nil },
+				func() error { return ErrCodeInvalidParams },
+			},
+			2,
+		},
+		{
+			"panic",
+			[]func() error{
+				func() error { panic("test panic") },
+				func() error { panic(ErrUserNotFound) },
+			},
+			2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var g Group
+			for _, f := range tt.funcs {
+				g.Go(f)
+			}
+			err := g.Wait()
+			if tt.wantLen == 0 {
+				if err != nil {
+					t.Errorf("Wait() error = %v, want nil", err)
+				}
+				return
+			}
+			errs := err.(*withJoin).Unwrap()
+			if len(errs) != tt.wantLen {
+				t.Errorf("Wait() errors = %v, want len %v", errs, tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestGroup_panic(t *testing.T) {
+	var g Group
+	g.Go(func() error { panic("test panic") })
+	g.Go(func() error { panic(ErrUserNotFound) })
+	err := g.Wait()
+	if !Is(err, ErrUserNotFound) {
+		t.Errorf("Wait() error = %v, want %v", err, ErrUserNotFound)
+	}
+	for _, e := range err.(*withJoin).Unwrap() {
+		if !stackExists(e) {
+			t.Errorf("Wait() error %v should have stack information", e)
+		}
+		str := fmt.Sprintf("%+v", e)
+		if !strings.Contains(str, "runtime.gopanic") || !strings.Contains(str, "TestGroup_panic") {
+			t.Errorf("Wait() error stack = %v, want panic site", str)
+		}
+	}
+}
+
+func TestGroup_failFast(t *testing.T) {
+	g, ctx := NewGroup(context.Background(), GroupFailFast)
+	g.Go(func() error {
+		return ErrCodeUserNotFound
+	})
+	g.Go(func() error {
+		select {
+		case <-ctx.Done():
+			return Wrap(ctx.Err(), "canceled")
+		case <-time.After(time.Second):
+			return nil
+		}
+	})
+	err := g.Wait()
+	if !Is(err, ErrCodeUserNotFound) || !Is(err, context.Canceled) {
+		t.Errorf("Wait() error = %v, want %v and %v", err, ErrCodeUserNotFound, context.Canceled)
+	}
+}
+
+func TestGroup_collectAll(t *testing.T) {
+	g, ctx := NewGroup(context.Background(), GroupCollectAll)
+	g.Go(func() error {
+		return ErrCodeUserNotFound
+	})
+	g.Go(func() error {
+		time.Sleep(10 * time.Millisecond)
+		return ctx.Err()
+	})
+	err := g.Wait()
+	if Is(err, context.Canceled) {
+		t.Errorf("Wait() error = %v, context should not be canceled", err)
+	}
+	if ctx.Err() == nil {
+		t.Errorf("Wait() did not cancel the context")
+	}
+}
+
+func TestGroup_SetLimit(t *testing.T) {
+	var g Group
+	g.SetLimit(2)
+	var active, maxActive int32
+	for i := 0; i < 10; i++ {
+		i := i
+		g.Go(func() error {
+			n := atomic.AddInt32(&active, 1)
+			for {
+				m := atomic.LoadInt32(&maxActive)
+				if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&active, -1)
+			return NewWithMessage("err %d", i)
+		})
+	}
+	err := g.Wait()
+	if maxActive > 2 {
+		t.Errorf("SetLimit() active = %v, want <= %v", maxActive, 2)
+	}
+	if got := len(err.(*withJoin).Unwrap()); got != 10 {
+		t.Errorf("Wait() errors len = %v, want %v", got, 10)
+	}
+}
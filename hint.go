@@ -0,0 +1,129 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+)
+
+// WithHint annotates err with a hint telling users how to fix the problem
+// The hint does not change Error, it appears in %+v and Tree.
+// If err is nil, WithHint returns nil.
+func WithHint(err error, hint string) error {
+	if err == nil {
+		return nil
+	}
+	return &withHint{
+		error: err,
+		hint:  hint,
+	}
+}
+
+// WithDetail annotates err with details for operators
+// The detail does not change Error, it appears in %+v and Tree.
+// If err is nil, WithDetail returns nil.
+func WithDetail(err error, detail string) error {
+	if err == nil {
+		return nil
+	}
+	return &withDetail{
+		error:  err,
+		detail: detail,
+	}
+}
+
+// Hints returns the hints in the chain and joins of err, outermost first and deduplicated
+func Hints(err error) []string {
+	var hints []string
+	walk(err, func(err error) {
+		if e, ok := err.(*withHint); ok {
+			hints = appendUnique(hints, e.hint)
+		}
+	})
+	return hints
+}
+
+// Details returns the details in the chain and joins of err, outermost first and deduplicated
+func Details(err error) []string {
+	var details []string
+	walk(err, func(err error) {
+		if e, ok := err.(*withDetail); ok {
+			details = appendUnique(details, e.detail)
+		}
+	})
+	return details
+}
+
+func appendUnique(s []string, v string) []string {
+	for _, e := range s {
+		if e == v {
+			return s
+		}
+	}
+	return append(s, v)
+}
+
+type withHint struct {
+	error
+	hint string
+}
+
+func (w *withHint) errorOf() error {
+	return w.error
+}
+
+func (w *withHint) Cause() error {
+	return w.error
+}
+
+func (w *withHint) Unwrap() error {
+	return w.error
+}
+
+func (w *withHint) Format(s fmt.State, verb rune) {
+	formatAnnotation(s, verb, w, w.error, "hint: "+w.hint)
+}
+
+type withDetail struct {
+	error
+	detail string
+}
+
+func (w *withDetail) errorOf() error {
+	return w.error
+}
+
+func (w *withDetail) Cause() error {
+	return w.error
+}
+
+func (w *withDetail) Unwrap() error {
+	return w.error
+}
+
+func (w *withDetail) Format(s fmt.State, verb rune) {
+	formatAnnotation(s, verb, w, w.error, "detail: "+w.detail)
+}
+
+// formatAnnotation formats err that annotates cause, %+v prints the annotation after cause
+// Annotations that are not printed, such as public messages, pass an empty annotation.
+func formatAnnotation(s fmt.State, verb rune, err, cause error, annotation string) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			if causedByStyle() {
+				io.WriteString(s, causedBy(err))
+				return
+			}
+			fmt.Fprintf(s, "%+v", cause)
+			if annotation != "" {
+				io.WriteString(s, "\n"+annotation)
+			}
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, err.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", err.Error())
+	}
+}
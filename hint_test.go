@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHints(t *testing.T) {
+	err := WithHint(
+		Wrap(
+			Join(
+				WithHint(WithDetail(New("bucket not found"), "bucket: logs"), "check that the bucket exists"),
+				WithHint(New("access denied"), "check the credentials"),
+			),
+			"upload",
+		),
+		"check that the bucket exists",
+	)
+	wantHints := []string{"check that the bucket exists", "check the credentials"}
+	if got := Hints(err); !reflect.DeepEqual(got, wantHints) {
+		t.Errorf("Hints() = %v, want %v", got, wantHints)
+	}
+	if got := Details(err); !reflect.DeepEqual(got, []string{"bucket: logs"}) {
+		t.Errorf("Details() = %v, want %v", got, []string{"bucket: logs"})
+	}
+	if got := Hints(New("go err")); got != nil {
+		t.Errorf("Hints() = %v, want nil", got)
+	}
+}
+
+func TestWithHint(t *testing.T) {
+	if WithHint(nil, "hint") != nil || WithDetail(nil, "detail") != nil {
+		t.Errorf("WithHint() want nil")
+	}
+	err := WithDetail(WithHint(ErrCodeUserNotFound, "check the user id"), "id: 1")
+	if err.Error() != ErrCodeUserNotFound.Error() {
+		t.Errorf("Error() = %v, want %v", err, ErrCodeUserNotFound)
+	}
+	if !Is(err, ErrCodeUserNotFound) || LatestCode(err) == nil {
+		t.Errorf("WithHint() hides %v", ErrCodeUserNotFound)
+	}
+	want := "500201010: user not found\nhint: check the user id\ndetail: id: 1"
+	if got := fmt.Sprintf("%+v", err); got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+	if got := Tree(err); !strings.Contains(got, "detail: id: 1\n└── hint: check the user id\n    └── [500201010] user not found") {
+		t.Errorf("Tree() = %v", got)
+	}
+}
+
+func TestWithHint_causedBy(t *testing.T) {
+	SetStackStyle(StackStyleCausedBy)
+	defer SetStackStyle(StackStyleFull)
+
+	err := WithDetail(WrapForce(WithHint(traceInner(), "check the bucket"), "upload"), "bucket: logs")
+	got := fmt.Sprintf("%+v", err)
+	hint := strings.Index(got, "\nhint: check the bucket")
+	detail := strings.Index(got, "\ndetail: bucket: logs")
+	if hint < 0 || detail < hint || !strings.HasSuffix(got, "\ndetail: bucket: logs") {
+		t.Errorf("Format() = %s", got)
+	}
+	if !strings.Contains(got, "\nCaused by: err1 -> {go err}") {
+		t.Errorf("Format() = %s", got)
+	}
+}
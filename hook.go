@@ -0,0 +1,77 @@
+package errors
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Event describes a stacked error passed to the hooks registered by OnCreate
+type Event struct {
+	// Code is the latest code of Err, or 0 if it has none
+	Code int
+	// Message is the latest message of Err, or Err.Error() if it has none
+	Message string
+	// Frame is the frame where the stack was captured
+	Frame runtime.Frame
+	Err   error
+}
+
+type hook struct {
+	fn func(ev Event)
+}
+
+var (
+	hooksMu sync.Mutex
+	// hooks holds a []*hook that is replaced, never modified, on registration
+	hooks atomic.Value
+)
+
+// OnCreate registers fn to be called whenever a stack is captured for an error,
+// by NewWithStack, WithStack, Wrap, Recover and the other stacked constructors
+// fn is called synchronously and must be safe for concurrent use.
+// The returned func unregisters fn.
+func OnCreate(fn func(ev Event)) (unregister func()) {
+	h := &hook{fn: fn}
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	old, _ := hooks.Load().([]*hook)
+	hooks.Store(append(old[:len(old):len(old)], h))
+	return func() {
+		hooksMu.Lock()
+		defer hooksMu.Unlock()
+		old, _ := hooks.Load().([]*hook)
+		hs := make([]*hook, 0, len(old))
+		for _, v := range old {
+			if v != h {
+				hs = append(hs, v)
+			}
+		}
+		hooks.Store(hs)
+	}
+}
+
+// created calls the registered hooks for w and returns w
+func created(w *withStack) *withStack {
+	hs, _ := hooks.Load().([]*hook)
+	if len(hs) == 0 {
+		return w
+	}
+	ev := Event{
+		Message: w.Error(),
+		Err:     w,
+	}
+	if c := LatestCode(w); c != nil {
+		ev.Code = c.Code()
+	}
+	if m := LatestMessage(w); m != nil {
+		ev.Message = m.Message()
+	}
+	if w.stack != nil {
+		ev.Frame, _ = w.stack.frame()
+	}
+	for _, h := range hs {
+		h.fn(ev)
+	}
+	return w
+}
@@ -0,0 +1,135 @@
+package errors
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestOnCreate(t *testing.T) {
+	var events []Event
+	stacked := WithStack(New("err"))
+	unregister := OnCreate(func(ev Event) {
+		events = append(events, ev)
+	})
+	tests := []struct {
+		name        string
+		create      func() error
+		wantEvents  int
+		wantCode    int
+		wantMessage string
+	}{
+		{
+			"NewWithStack",
+			func() error { return NewWithStack("user %d", 1) },
+			1,
+			0,
+			"user 1",
+		},
+		{
+			"Wrap code",
+			func() error { return Wrap(ErrCodeUserNotFound, "query") },
+			1,
+			CodeUserNotFound,
+			"query",
+		},
+		{
+			"Wrap stacked",
+			func() error { return Wrap(stacked, "query") },
+			0,
+			0,
+			"",
+		},
+		{
+			"WrapStack",
+			func() error { return ErrCodeInvalidParams.WrapStack(New("err")) },
+			1,
+			CodeInvalidParams,
+			"invalid params",
+		},
+		{
+			"no stack",
+			func() error { return NewWithMessage("err") },
+			0,
+			0,
+			"",
+		},
+		{
+			"Recover",
+			func() (err error) {
+				defer Recover(func(e error) { err = e })
+				panic("boom")
+			},
+			1,
+			0,
+			"boom",
+		},
+		{
+			"Recover CheckWithStack",
+			func() (err error) {
+				defer Recover(func(e error) { err = e })
+				CheckWithStack(ErrCodeUserNotFound)
+				return nil
+			},
+			1,
+			CodeUserNotFound,
+			"user not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events = nil
+			err := tt.create()
+			if len(events) != tt.wantEvents {
+				t.Fatalf("OnCreate() events = %v, want %v", len(events), tt.wantEvents)
+			}
+			if tt.wantEvents == 0 {
+				return
+			}
+			ev := events[len(events)-1]
+			if ev.Err != err {
+				t.Errorf("Event.Err = %v, want %v", ev.Err, err)
+			}
+			if ev.Code != tt.wantCode {
+				t.Errorf("Event.Code = %v, want %v", ev.Code, tt.wantCode)
+			}
+			if ev.Message != tt.wantMessage {
+				t.Errorf("Event.Message = %v, want %v", ev.Message, tt.wantMessage)
+			}
+			if !strings.HasSuffix(ev.Frame.File, "hook_test.go") {
+				t.Errorf("Event.Frame = %v, want hook_test.go", ev.Frame.File)
+			}
+		})
+	}
+	unregister()
+	events = nil
+	_ = NewWithStack("err")
+	if len(events) != 0 {
+		t.Errorf("OnCreate() events after unregister = %v, want 0", len(events))
+	}
+}
+
+func TestOnCreate_concurrent(t *testing.T) {
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		count int
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unregister := OnCreate(func(ev Event) {
+				mu.Lock()
+				count++
+				mu.Unlock()
+			})
+			_ = WithStack(New("err"))
+			unregister()
+		}()
+	}
+	wg.Wait()
+	if count == 0 {
+		t.Errorf("OnCreate() events = 0, want > 0")
+	}
+}
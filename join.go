@@ -3,6 +3,7 @@ package errors
 import (
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -33,43 +34,302 @@ func NewWithJoin(errs ...error) ErrorJoin {
 	return e
 }
 
+// NewWithJoinOptions returns an empty ErrorJoin configured by opts
+func NewWithJoinOptions(opts ...JoinOption) ErrorJoin {
+	e := &withJoin{}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// JoinOption configures an ErrorJoin created by NewWithJoinOptions
+type JoinOption func(*withJoin)
+
+// JoinSummary renders the join as a summary grouped by code or message,
+// showing at most maxEntries groups
+func JoinSummary(maxEntries int) JoinOption {
+	return func(w *withJoin) {
+		w.summary = maxEntries
+	}
+}
+
+// JoinMaxErrors keeps the first n errors and counts the rest as dropped
+func JoinMaxErrors(n int) JoinOption {
+	return func(w *withJoin) {
+		w.maxErrors = n
+	}
+}
+
+// JoinDedup drops errors that are duplicates of a joined error by mode, and counts them as dropped
+func JoinDedup(mode DedupMode) JoinOption {
+	return func(w *withJoin) {
+		w.dedup |= mode
+	}
+}
+
+type DedupMode int
+
+const (
+	// DedupIs treats err as a duplicate if Is(err, joined) is true
+	DedupIs DedupMode = 1 << iota
+	// DedupCode treats err as a duplicate if it has the same latest code as a joined error
+	DedupCode
+	// DedupStack treats err as a duplicate if it has the same stack as a joined error
+	DedupStack
+	// DedupFingerprint treats err as a duplicate if it has the same Fingerprint as a joined error
+	DedupFingerprint
+)
+
+// JoinSeparator separates the errors in Error, %s, %v and %q, the default is a newline
+func JoinSeparator(sep string) JoinOption {
+	return func(w *withJoin) {
+		w.separator = sep
+		w.hasSeparator = true
+	}
+}
+
+// JoinNumbered renders the errors as a numbered list, starting at 1
+func JoinNumbered() JoinOption {
+	return func(w *withJoin) {
+		w.numbered = true
+	}
+}
+
+// JoinIndent indents the continuation lines of each error, such as nested joins and stacks
+// Numbered errors are indented to the width of their number by default.
+func JoinIndent(indent string) JoinOption {
+	return func(w *withJoin) {
+		w.indent = indent
+		w.hasIndent = true
+	}
+}
+
+type joinOptions struct {
+	summary      int
+	maxErrors    int
+	dedup        DedupMode
+	separator    string
+	hasSeparator bool
+	numbered     bool
+	indent       string
+	hasIndent    bool
+	// primary makes LatestCode return the code of the first error, even if it has none
+	primary bool
+}
+
 type ErrorJoin interface {
 	error
 	Append(error)
 	Unwrap() []error
 	Len() int
 	ToError() error
+	Err() error
+	Freeze() error
+	Summary() []SummaryGroup
+	Dropped() int
+	Errors() []error
+	Filter(pred func(err error) bool)
+	Remove(target error)
+	Reset()
+	Flatten()
 }
 
+// withJoin is safe for concurrent use, reads return a consistent snapshot of errs
 type withJoin struct {
-	errs []error
-	mu   sync.Mutex
+	errs    []error
+	dropped int
+	frozen  bool
+	// errKeys are the dedup keys of each of errs, keys is their set
+	errKeys [][]string
+	keys    map[string]struct{}
+	// gen changes whenever errs is replaced rather than appended to
+	gen int
+	joinOptions
+	mu sync.RWMutex
 }
 
-func (w *withJoin) Error() string {
+func (w *withJoin) snapshot() []error {
+	errs, _ := w.view()
+	return errs
+}
+
+// view returns a copy of errs and the dropped count
+func (w *withJoin) view() ([]error, int) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
 	if len(w.errs) == 0 {
+		return nil, w.dropped
+	}
+	errs := make([]error, len(w.errs))
+	copy(errs, w.errs)
+	return errs, w.dropped
+}
+
+func (w *withJoin) Error() string {
+	errs, dropped := w.view()
+	if len(errs) == 0 {
 		return ""
 	}
+	return w.render(errs, dropped, false, formatter())
+}
+
+// render renders errs with the join options, each error in full with stack if plus is set
+// Without layout options, errs are composed by f.
+func (w *withJoin) render(errs []error, dropped int, plus bool, f Formatter) string {
+	sep := "\n"
+	if w.hasSeparator && !plus {
+		sep = w.separator
+	}
+	if w.summary > 0 {
+		return summarize(errs, w.summary, plus) + droppedString(sep, dropped)
+	}
+	if !plus && !w.hasSeparator && !w.numbered && !w.hasIndent {
+		bound := make([]error, len(errs))
+		for i, err := range errs {
+			bound[i] = bind(err, f)
+		}
+		return f.FormatJoin(bound) + droppedString(sep, dropped)
+	}
 	var builder strings.Builder
-	builder.WriteString(w.errs[0].Error())
-	for _, err := range w.errs[1:] {
-		builder.WriteByte('\n')
-		builder.WriteString(err.Error())
+	for i, err := range errs {
+		if i > 0 {
+			builder.WriteString(sep)
+		}
+		prefix := ""
+		if w.numbered {
+			prefix = strconv.Itoa(i+1) + ". "
+		}
+		indent := strings.Repeat(" ", len(prefix))
+		if w.hasIndent {
+			indent = w.indent
+		}
+		var text string
+		if plus {
+			text = strings.TrimSuffix(fmt.Sprintf("%+v", err), "\n")
+		} else {
+			text = renderWith(err, f)
+		}
+		builder.WriteString(prefix)
+		builder.WriteString(strings.ReplaceAll(text, "\n", "\n"+indent))
 	}
+	builder.WriteString(droppedString(sep, dropped))
 	return builder.String()
 }
 
+func droppedString(sep string, dropped int) string {
+	if dropped == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s(%d more errors dropped)", sep, dropped)
+}
+
+// Append adds err to the join, errors appended to a frozen join are counted as dropped
+// With DedupIs, Is runs on a snapshot without holding the lock.
 func (w *withJoin) Append(err error) {
 	if err == nil {
 		return
 	}
+	keys := w.dedupKeys(err)
+	gen, checked := -1, 0
+	for {
+		if w.dedup&DedupIs != 0 {
+			w.mu.RLock()
+			if w.gen != gen {
+				gen, checked = w.gen, 0
+			}
+			errs := append([]error(nil), w.errs[checked:]...)
+			w.mu.RUnlock()
+			for _, e := range errs {
+				if Is(err, e) {
+					w.drop()
+					return
+				}
+			}
+			checked += len(errs)
+		}
+		if w.tryAppend(err, keys, gen, checked) {
+			return
+		}
+	}
+}
+
+// tryAppend appends err unless it is dropped, and reports whether it is done
+// With DedupIs, it is not done if errs changed since the first checked errors of generation gen were compared.
+func (w *withJoin) tryAppend(err error, keys []string, gen, checked int) bool {
 	w.mu.Lock()
 	defer w.mu.Unlock()
+	if w.dedup&DedupIs != 0 && (w.gen != gen || len(w.errs) != checked) {
+		return false
+	}
+	if w.frozen || (w.maxErrors > 0 && len(w.errs) >= w.maxErrors) || w.duplicate(keys) {
+		w.dropped++
+		return true
+	}
 	w.errs = append(w.errs, err)
+	if w.dedup&^DedupIs != 0 {
+		w.errKeys = append(w.errKeys, keys)
+		w.addKeys(keys)
+	}
+	return true
+}
+
+func (w *withJoin) drop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.dropped++
+}
+
+// dedupKeys returns the keys of err for the DedupCode, DedupStack and DedupFingerprint modes
+// They are computed once per error, without holding the lock.
+func (w *withJoin) dedupKeys(err error) []string {
+	var keys []string
+	if w.dedup&DedupCode != 0 {
+		if c := LatestCode(err); c != nil {
+			keys = append(keys, "code:"+strconv.Itoa(c.Code()))
+		}
+	}
+	if w.dedup&DedupStack != 0 {
+		if st := stackOf(err); st != nil {
+			keys = append(keys, "stack:"+st.key())
+		}
+	}
+	if w.dedup&DedupFingerprint != 0 {
+		keys = append(keys, "fingerprint:"+Fingerprint(err))
+	}
+	return keys
+}
+
+func (w *withJoin) addKeys(keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	if w.keys == nil {
+		w.keys = make(map[string]struct{})
+	}
+	for _, key := range keys {
+		w.keys[key] = struct{}{}
+	}
+}
+
+func (w *withJoin) duplicate(keys []string) bool {
+	for _, key := range keys {
+		if _, ok := w.keys[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Dropped returns the number of errors dropped by JoinMaxErrors and JoinDedup
+func (w *withJoin) Dropped() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.dropped
 }
 
 func (w *withJoin) Is(err error) bool {
-	for _, e := range w.errs {
+	for _, e := range w.snapshot() {
 		if Is(e, err) {
 			return true
 		}
@@ -77,34 +337,193 @@ func (w *withJoin) Is(err error) bool {
 	return false
 }
 
+// Unwrap returns a copy of the joined errors
 func (w *withJoin) Unwrap() []error {
-	return w.errs
+	return w.snapshot()
 }
 
 func (w *withJoin) Format(s fmt.State, verb rune) {
+	errs, dropped := w.view()
 	switch verb {
 	case 'v':
 		if s.Flag('+') {
-			for _, err := range w.errs {
-				fmt.Fprintf(s, "%+v\n", err)
+			if causedByStyle() {
+				io.WriteString(s, causedBy(w))
+				return
+			}
+			if len(errs) > 0 {
+				io.WriteString(s, w.render(errs, dropped, true, nil))
+				io.WriteString(s, "\n")
 			}
 			return
 		}
 		fallthrough
-	case 's', 'q':
-		for _, err := range w.errs {
-			io.WriteString(s, err.Error())
+	case 's':
+		if len(errs) > 0 {
+			io.WriteString(s, w.render(errs, dropped, false, formatter()))
+		}
+	case 'q':
+		text := ""
+		if len(errs) > 0 {
+			text = w.render(errs, dropped, false, formatter())
 		}
+		fmt.Fprintf(s, "%q", text)
 	}
 }
 
 func (w *withJoin) Len() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
 	return len(w.errs)
 }
 
 func (w *withJoin) ToError() error {
-	if len(w.errs) == 0 {
+	if w.Len() == 0 {
 		return nil
 	}
 	return w
 }
+
+// Err returns an immutable snapshot of the joined errors, as a frozen join
+// If there are no errors, Err returns nil.
+func (w *withJoin) Err() error {
+	errs, dropped := w.view()
+	if len(errs) == 0 {
+		return nil
+	}
+	return &withJoin{
+		errs:        errs,
+		dropped:     dropped,
+		frozen:      true,
+		joinOptions: w.joinOptions,
+	}
+}
+
+// Errors returns a copy of the joined errors
+func (w *withJoin) Errors() []error {
+	return w.snapshot()
+}
+
+// Filter keeps only the errors for which pred returns true
+// pred runs on a snapshot without holding the lock, errors appended meanwhile are kept.
+func (w *withJoin) Filter(pred func(err error) bool) {
+	w.replace(func(errs []error) []error {
+		kept := make([]error, 0, len(errs))
+		for _, err := range errs {
+			if pred(err) {
+				kept = append(kept, err)
+			}
+		}
+		return kept
+	})
+}
+
+// Remove removes the errors that match target by Is
+func (w *withJoin) Remove(target error) {
+	w.Filter(func(err error) bool {
+		return !Is(err, target)
+	})
+}
+
+// Reset removes all errors and clears the dropped count
+func (w *withJoin) Reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.frozen {
+		return
+	}
+	w.errs = nil
+	w.errKeys = nil
+	w.keys = nil
+	w.dropped = 0
+	w.gen++
+}
+
+// Flatten replaces nested joins, including those from the standard library, with their errors
+func (w *withJoin) Flatten() {
+	w.replace(func(errs []error) []error {
+		return flatten(make([]error, 0, len(errs)), errs, map[*withJoin]bool{w: true})
+	})
+}
+
+// replace sets errs to f of a snapshot of errs, f runs without holding the lock
+// Errors appended while f runs are kept after the result. If errs is replaced meanwhile, f runs again.
+func (w *withJoin) replace(f func(errs []error) []error) {
+	for {
+		w.mu.RLock()
+		frozen, gen := w.frozen, w.gen
+		errs := make([]error, len(w.errs))
+		copy(errs, w.errs)
+		w.mu.RUnlock()
+		if frozen {
+			return
+		}
+		n := len(errs)
+		errs = f(errs)
+		var errKeys [][]string
+		if w.dedup&^DedupIs != 0 {
+			errKeys = make([][]string, len(errs))
+			for i, err := range errs {
+				errKeys[i] = w.dedupKeys(err)
+			}
+		}
+		if w.swap(gen, n, errs, errKeys) {
+			return
+		}
+	}
+}
+
+// swap replaces the first n errors with errs if gen is current, and reports whether it did
+func (w *withJoin) swap(gen, n int, errs []error, errKeys [][]string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.gen != gen {
+		return false
+	}
+	w.gen++
+	if w.frozen {
+		return true
+	}
+	w.errs = append(errs, w.errs[n:]...)
+	w.keys = nil
+	if errKeys != nil {
+		w.errKeys = append(errKeys, w.errKeys[n:]...)
+		for _, keys := range w.errKeys {
+			w.addKeys(keys)
+		}
+	}
+	return true
+}
+
+// flatten appends errs to dst with joins replaced by their errors
+// A join that contains itself, directly or through others, is left out once seen.
+func flatten(dst, errs []error, seen map[*withJoin]bool) []error {
+	for _, err := range errs {
+		if j, ok := err.(*withJoin); ok {
+			if seen[j] {
+				continue
+			}
+			seen[j] = true
+			dst = flatten(dst, j.Unwrap(), seen)
+			delete(seen, j)
+			continue
+		}
+		if j, ok := err.(joiner); ok {
+			dst = flatten(dst, j.Unwrap(), seen)
+			continue
+		}
+		if err != nil {
+			dst = append(dst, err)
+		}
+	}
+	return dst
+}
+
+// Freeze stops the join from accepting errors and returns it as ToError does
+// After Freeze, appended errors are counted as dropped and Filter, Remove, Reset and Flatten do nothing.
+func (w *withJoin) Freeze() error {
+	w.mu.Lock()
+	w.frozen = true
+	w.mu.Unlock()
+	return w.ToError()
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 )
 
 func TestJoin_nil(t *testing.T) {
@@ -198,9 +199,9 @@ func Test_withJoin_Format(t *testing.T) {
 		format   string
 		expected string
 	}{
-		{"%s", "error 1error 2error 3"},
-		{"%q", "error 1error 2error 3"},
-		{"%v", "error 1error 2error 3"},
+		{"%s", "error 1\nerror 2\nerror 3"},
+		{"%q", `"error 1\nerror 2\nerror 3"`},
+		{"%v", "error 1\nerror 2\nerror 3"},
 		{"%+v", "error 1\nerror 2\nerror 3\ngithub.com/ace-zhaoy/errors.Test_withJoin_Format"},
 	}
 	for _, tt := range tests {
@@ -211,3 +212,366 @@ func Test_withJoin_Format(t *testing.T) {
 		}
 	}
 }
+
+func Test_withJoin_concurrent(t *testing.T) {
+	const appenders = 64
+	const perAppender = 100
+	jerr := NewWithJoin()
+	done := make(chan struct{})
+	readers := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				_ = jerr.Error()
+				_ = jerr.Len()
+				_ = jerr.Unwrap()
+				_ = jerr.ToError()
+				_ = jerr.Err()
+				_ = Is(jerr, ErrUserNotFound)
+				_ = fmt.Sprintf("%v %+v", jerr, jerr)
+			}
+		}()
+	}
+	g := sync.WaitGroup{}
+	for i := 0; i < appenders; i++ {
+		g.Add(1)
+		go func(i int) {
+			defer g.Done()
+			for j := 0; j < perAppender; j++ {
+				jerr.Append(NewWithStack("err %d-%d", i, j))
+			}
+		}(i)
+	}
+	g.Wait()
+	close(done)
+	readers.Wait()
+	if jerr.Len() != appenders*perAppender {
+		t.Errorf("Len() = %v, want %v", jerr.Len(), appenders*perAppender)
+	}
+}
+
+func Test_withJoin_Err(t *testing.T) {
+	jerr := NewWithJoin()
+	if err := jerr.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+	err1 := New("err1")
+	err2 := New("err2")
+	jerr.Append(err1)
+	snapshot := jerr.Err()
+	jerr.Append(err2)
+	if got := len(snapshot.(*withJoin).Unwrap()); got != 1 {
+		t.Errorf("Err() len = %v, want %v", got, 1)
+	}
+	if !Is(snapshot, err1) || Is(snapshot, err2) {
+		t.Errorf("Err() = %v, want %v", snapshot, err1)
+	}
+
+	unwrapped := jerr.Unwrap()
+	unwrapped[0] = err2
+	if got := jerr.Unwrap()[0]; got != err1 {
+		t.Errorf("Unwrap() returned shared slice, got %v, want %v", got, err1)
+	}
+
+	snapshot.(ErrorJoin).Append(err2)
+	if got := snapshot.(ErrorJoin); got.Len() != 1 || got.Dropped() != 1 {
+		t.Errorf("Append() on Err() snapshot Len() = %v, Dropped() = %v, want 1, 1", got.Len(), got.Dropped())
+	}
+}
+
+func Test_withJoin_Freeze(t *testing.T) {
+	if err := NewWithJoin().Freeze(); err != nil {
+		t.Errorf("Freeze() = %v, want nil", err)
+	}
+	err1 := New("err1")
+	jerr := NewWithJoin(err1)
+	if err := jerr.Freeze(); err != jerr {
+		t.Errorf("Freeze() = %v, want %v", err, jerr)
+	}
+	jerr.Append(New("err2"))
+	if jerr.Len() != 1 || jerr.Dropped() != 1 {
+		t.Errorf("Append() after Freeze() Len() = %v, Dropped() = %v, want 1, 1", jerr.Len(), jerr.Dropped())
+	}
+}
+
+func newStackErr(message string) error {
+	return NewWithStack(message)
+}
+
+func Test_withJoin_options(t *testing.T) {
+	sameStack := make([]error, 2)
+	for i := range sameStack {
+		sameStack[i] = newStackErr("same stack")
+	}
+	tests := []struct {
+		name        string
+		opts        []JoinOption
+		errs        []error
+		wantLen     int
+		wantDropped int
+	}{
+		{
+			"no options",
+			nil,
+			[]error{New("err1"), New("err1"), New("err2")},
+			3,
+			0,
+		},
+		{
+			"max errors",
+			[]JoinOption{JoinMaxErrors(2)},
+			[]error{New("err1"), New("err2"), New("err3"), New("err4")},
+			2,
+			2,
+		},
+		{
+			"dedup is",
+			[]JoinOption{JoinDedup(DedupIs)},
+			[]error{New("err1"), Wrap(New("err1"), "wrap"), New("err2")},
+			2,
+			1,
+		},
+		{
+			"dedup code",
+			[]JoinOption{JoinDedup(DedupCode)},
+			[]error{ErrCodeUserNotFound, ErrCodeUserNotFound.WrapStack(New("err1")), ErrCodeInvalidParams, New("err1")},
+			3,
+			1,
+		},
+		{
+			"dedup stack",
+			[]JoinOption{JoinDedup(DedupStack)},
+			append([]error{New("err1"), New("err1"), newStackErr("other stack")}, sameStack...),
+			4,
+			1,
+		},
+		{
+			"dedup and max errors",
+			[]JoinOption{JoinDedup(DedupIs), JoinMaxErrors(1)},
+			[]error{New("err1"), New("err1"), New("err2")},
+			1,
+			2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jerr := NewWithJoinOptions(tt.opts...)
+			for _, err := range tt.errs {
+				jerr.Append(err)
+			}
+			if jerr.Len() != tt.wantLen {
+				t.Errorf("Len() = %v, want %v", jerr.Len(), tt.wantLen)
+			}
+			if jerr.Dropped() != tt.wantDropped {
+				t.Errorf("Dropped() = %v, want %v", jerr.Dropped(), tt.wantDropped)
+			}
+			if snapshot := jerr.Err().(ErrorJoin); snapshot.Dropped() != tt.wantDropped {
+				t.Errorf("Err().Dropped() = %v, want %v", snapshot.Dropped(), tt.wantDropped)
+			}
+		})
+	}
+}
+
+func Test_withJoin_dedupRemove(t *testing.T) {
+	jerr := NewWithJoinOptions(JoinDedup(DedupCode | DedupFingerprint))
+	jerr.Append(ErrCodeUserNotFound)
+	jerr.Remove(ErrCodeUserNotFound)
+	jerr.Append(ErrCodeUserNotFound)
+	jerr.Append(ErrCodeUserNotFound)
+	if jerr.Len() != 1 || jerr.Dropped() != 1 {
+		t.Errorf("Len() = %v, Dropped() = %v, want 1, 1", jerr.Len(), jerr.Dropped())
+	}
+	jerr.Reset()
+	jerr.Append(ErrCodeUserNotFound)
+	if jerr.Len() != 1 {
+		t.Errorf("Len() = %v, want 1", jerr.Len())
+	}
+}
+
+func Test_withJoin_droppedError(t *testing.T) {
+	jerr := NewWithJoinOptions(JoinMaxErrors(1))
+	jerr.Append(New("err1"))
+	jerr.Append(New("err2"))
+	jerr.Append(New("err3"))
+	want := "err1\n(2 more errors dropped)"
+	if got := jerr.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got := fmt.Sprintf("%+v", jerr); got != "err1\n(2 more errors dropped)\n" {
+		t.Errorf("Format() = %q, want %q", got, "err1\n(2 more errors dropped)\n")
+	}
+}
+
+type stdJoin []error
+
+func (e stdJoin) Error() string {
+	return "std join"
+}
+
+func (e stdJoin) Unwrap() []error {
+	return e
+}
+
+func Test_withJoin_manipulation(t *testing.T) {
+	err1 := New("err1")
+	err2 := New("err2")
+	err3 := New("err3")
+	err4 := New("err4")
+	tests := []struct {
+		name   string
+		errs   []error
+		modify func(ErrorJoin)
+		want   []error
+	}{
+		{
+			"filter",
+			[]error{err1, err2, err3},
+			func(jerr ErrorJoin) {
+				jerr.Filter(func(err error) bool {
+					return err != err2
+				})
+			},
+			[]error{err1, err3},
+		},
+		{
+			"remove",
+			[]error{err1, Wrap(err2, "wrap"), err3, err2},
+			func(jerr ErrorJoin) {
+				jerr.Remove(err2)
+			},
+			[]error{err1, err3},
+		},
+		{
+			"reset",
+			[]error{err1, err2},
+			func(jerr ErrorJoin) {
+				jerr.Reset()
+			},
+			nil,
+		},
+		{
+			"flatten",
+			[]error{err1, Join(err2, NewWithJoin(err3)), stdJoin{err4, nil}},
+			func(jerr ErrorJoin) {
+				jerr.Flatten()
+			},
+			[]error{err1, err2, err3, err4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jerr := NewWithJoin(tt.errs...)
+			tt.modify(jerr)
+			got := jerr.Errors()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Errors() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Errors()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_withJoin_manipulationReentrant(t *testing.T) {
+	err1, err2 := New("err1"), New("err2")
+	jerr := NewWithJoin(err1, err2)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		jerr.Filter(func(err error) bool {
+			return jerr.Len() > 0 && err != err1
+		})
+		jerr.Append(jerr)
+		jerr.Flatten()
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Filter() and Flatten() deadlocked")
+	}
+	if got := jerr.Errors(); len(got) != 1 || got[0] != err2 {
+		t.Errorf("Errors() = %v, want %v", got, []error{err2})
+	}
+}
+
+func Test_withJoin_dedupIsReentrant(t *testing.T) {
+	jerr := NewWithJoinOptions(JoinDedup(DedupIs))
+	jerr.Append(New("err1"))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		jerr.Append(WithStack(jerr))
+		jerr.Append(New("err1"))
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Append() deadlocked")
+	}
+	if jerr.Len() != 1 || jerr.Dropped() != 2 {
+		t.Errorf("Len() = %v, Dropped() = %v, want 1, 2", jerr.Len(), jerr.Dropped())
+	}
+}
+
+func Test_withJoin_dedupIsConcurrent(t *testing.T) {
+	jerr := NewWithJoinOptions(JoinDedup(DedupIs))
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			jerr.Append(NewWithMessage("err%d", i%10))
+		}(i)
+	}
+	wg.Wait()
+	if jerr.Len() != 10 || jerr.Dropped() != 90 {
+		t.Errorf("Len() = %v, Dropped() = %v, want 10, 90", jerr.Len(), jerr.Dropped())
+	}
+}
+
+func Test_withJoin_FilterConcurrent(t *testing.T) {
+	jerr := NewWithJoin()
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			jerr.Append(New("keep"))
+		}()
+		go func() {
+			defer wg.Done()
+			jerr.Filter(func(err error) bool { return err.Error() == "keep" })
+		}()
+	}
+	wg.Wait()
+	if jerr.Len() != 100 {
+		t.Errorf("Len() = %v, want %v", jerr.Len(), 100)
+	}
+}
+
+func Test_withJoin_manipulationFrozen(t *testing.T) {
+	jerr := NewWithJoin(New("err1"))
+	jerr.Freeze()
+	modifies := []func(){
+		func() { jerr.Filter(func(error) bool { return false }) },
+		func() { jerr.Remove(New("err1")) },
+		jerr.Reset,
+		jerr.Flatten,
+	}
+	for i, modify := range modifies {
+		modify()
+		if jerr.Len() != 1 {
+			t.Errorf("modify %d on frozen join Len() = %v, want %v", i, jerr.Len(), 1)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package errors
+
+import (
+	"sort"
+	"sync"
+)
+
+// ErrorCount is the number of errors counted with the same source, code and severity
+type ErrorCount struct {
+	// Source is "created" for errors counted by EnableMetrics and "observed" for Observe
+	Source   string `json:"source"`
+	Code     int    `json:"code"`
+	Severity string `json:"severity"`
+	Count    int64  `json:"count"`
+}
+
+type metricKey struct {
+	source   string
+	code     int
+	severity SeverityLevel
+}
+
+var (
+	metricsMu sync.Mutex
+	metrics   = make(map[metricKey]int64)
+)
+
+// Observe counts err in the metrics and keeps it for Recent,
+// use it where errors are handled, such as logging or responding
+// If err is nil, Observe does nothing.
+func Observe(err error) {
+	if err == nil {
+		return
+	}
+	count("observed", err)
+	recent.add(err)
+}
+
+// EnableMetrics counts every stacked error when it is created, through an OnCreate hook
+// The returned func stops counting.
+func EnableMetrics() (disable func()) {
+	return OnCreate(func(ev Event) {
+		count("created", ev.Err)
+	})
+}
+
+func count(source string, err error) {
+	key := metricKey{
+		source:   source,
+		severity: Severity(err),
+	}
+	if c := LatestCode(err); c != nil {
+		key.code = c.Code()
+	}
+	metricsMu.Lock()
+	metrics[key]++
+	metricsMu.Unlock()
+}
+
+// Metrics returns the error counts sorted by source, code and severity
+func Metrics() []ErrorCount {
+	metricsMu.Lock()
+	keys := make([]metricKey, 0, len(metrics))
+	for k := range metrics {
+		keys = append(keys, k)
+	}
+	counts := make([]ErrorCount, 0, len(keys))
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].source != keys[j].source {
+			return keys[i].source < keys[j].source
+		}
+		if keys[i].code != keys[j].code {
+			return keys[i].code < keys[j].code
+		}
+		return keys[i].severity < keys[j].severity
+	})
+	for _, k := range keys {
+		counts = append(counts, ErrorCount{
+			Source:   k.source,
+			Code:     k.code,
+			Severity: k.severity.String(),
+			Count:    metrics[k],
+		})
+	}
+	metricsMu.Unlock()
+	return counts
+}
+
+// ResetMetrics sets all error counts to zero
+func ResetMetrics() {
+	metricsMu.Lock()
+	metrics = make(map[metricKey]int64)
+	metricsMu.Unlock()
+}
@@ -0,0 +1,31 @@
+package errors
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	ResetMetrics()
+	defer ResetMetrics()
+	disable := EnableMetrics()
+	_ = Wrap(ErrCodeUserNotFound, "query")
+	_ = NewWithStack("err")
+	disable()
+	_ = NewWithStack("err")
+	Observe(nil)
+	Observe(Wrap(ErrCodeUserNotFound, "query"))
+	Observe(WithSeverity(ErrCodeUserNotFound, SeverityCritical))
+	Observe(ErrCodeUserNotFound)
+
+	want := []ErrorCount{
+		{"created", 0, "error", 1},
+		{"created", CodeUserNotFound, "error", 1},
+		{"observed", CodeUserNotFound, "error", 2},
+		{"observed", CodeUserNotFound, "critical", 1},
+	}
+	if got := Metrics(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Metrics() = %v, want %v", got, want)
+	}
+
+}
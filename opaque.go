@@ -0,0 +1,89 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+)
+
+// Opaque hides the chain of err from Is, As, Unwrap and Cause at a library boundary
+// Error is unchanged, the chain is kept for %+v, Tree and Reveal.
+// If err is nil, Opaque returns nil.
+func Opaque(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &withOpaque{
+		hidden: err,
+	}
+}
+
+// Boundary returns code with err hidden as its cause, callers only see code
+// Error is that of code, err is kept for %+v, Tree and Reveal.
+// If err is nil, Boundary returns nil.
+func Boundary(code ErrorCode, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &withOpaque{
+		visible: code,
+		hidden:  err,
+	}
+}
+
+// Reveal returns the error hidden by the outermost Opaque or Boundary in the chain of err,
+// for diagnostic tools. If there is none, Reveal returns nil.
+func Reveal(err error) error {
+	var hidden error
+	walk(err, func(err error) {
+		if e, ok := err.(*withOpaque); ok && hidden == nil {
+			hidden = e.hidden
+		}
+	})
+	return hidden
+}
+
+type withOpaque struct {
+	visible error
+	hidden  error
+}
+
+func (w *withOpaque) Error() string {
+	return w.errorOf().Error()
+}
+
+func (w *withOpaque) errorOf() error {
+	if w.visible == nil {
+		return w.hidden
+	}
+	return w.visible
+}
+
+func (w *withOpaque) Cause() error {
+	return w.visible
+}
+
+func (w *withOpaque) Unwrap() error {
+	return w.visible
+}
+
+func (w *withOpaque) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			if causedByStyle() {
+				io.WriteString(s, causedBy(w))
+				return
+			}
+			fmt.Fprintf(s, "%+v", w.hidden)
+			if w.visible != nil {
+				fmt.Fprintf(s, "\n%+v", w.visible)
+			}
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, w.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", w.Error())
+	}
+}
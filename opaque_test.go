@@ -0,0 +1,93 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestOpaque(t *testing.T) {
+	errInternal := NewWithMessage("connection refused")
+	internal := Wrap(ErrCodeUserNotFound.WrapStack(errInternal), "query")
+	tests := []struct {
+		name     string
+		err      error
+		wantErr  string
+		wantCode int
+		wantIs   []error
+	}{
+		{
+			"opaque",
+			Opaque(internal),
+			"query -> {[500201010: user not found] -> {connection refused}}",
+			0,
+			nil,
+		},
+		{
+			"boundary",
+			Boundary(ErrCodeInvalidParams, internal),
+			"[400102030: invalid params]",
+			CodeInvalidParams,
+			[]error{ErrCodeInvalidParams},
+		},
+		{
+			"wrapped boundary",
+			Wrap(Boundary(ErrCodeInvalidParams, internal), "sdk"),
+			"sdk -> {[400102030: invalid params]}",
+			CodeInvalidParams,
+			[]error{ErrCodeInvalidParams},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.wantErr {
+				t.Errorf("Error() = %v, want %v", got, tt.wantErr)
+			}
+			for _, target := range []error{errInternal, ErrCodeUserNotFound} {
+				if Is(tt.err, target) {
+					t.Errorf("Is() = true for hidden %v", target)
+				}
+			}
+			for _, target := range tt.wantIs {
+				if !Is(tt.err, target) {
+					t.Errorf("Is() = false for %v", target)
+				}
+			}
+			c := LatestCode(tt.err)
+			if (c == nil && tt.wantCode != 0) || (c != nil && c.Code() != tt.wantCode) {
+				t.Errorf("LatestCode() = %v, want %v", c, tt.wantCode)
+			}
+			if Reveal(tt.err) != internal {
+				t.Errorf("Reveal() = %v, want %v", Reveal(tt.err), internal)
+			}
+			if str := fmt.Sprintf("%+v", tt.err); !strings.Contains(str, "connection refused\n") {
+				t.Errorf("Format() = %v, want hidden cause", str)
+			}
+		})
+	}
+}
+
+func TestOpaque_nil(t *testing.T) {
+	if Opaque(nil) != nil || Boundary(ErrCodeInvalidParams, nil) != nil {
+		t.Errorf("Opaque() want nil")
+	}
+	if Reveal(errors.New("go err")) != nil {
+		t.Errorf("Reveal() want nil")
+	}
+	var target *withMessage
+	if errors.As(Opaque(New("err")), &target) {
+		t.Errorf("As() = true, want false")
+	}
+}
+
+func TestBoundary_causedBy(t *testing.T) {
+	SetStackStyle(StackStyleCausedBy)
+	defer SetStackStyle(StackStyleFull)
+
+	got := fmt.Sprintf("%+v", Boundary(ErrCodeInvalidParams, traceInner()))
+	want := "[400102030: invalid params]\nCaused by: err1 -> {go err}\n\tat github.com/ace-zhaoy/errors.traceInner("
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("Format() = %s, want prefix %s", got, want)
+	}
+}
@@ -0,0 +1,129 @@
+package errors
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DefaultPublicMessage is the public message of errors without a public message or code
+var DefaultPublicMessage = "internal error"
+
+var publicMessages sync.Map
+
+// RegisterPublicMessage sets the default public message of code
+func RegisterPublicMessage(code int, message string) {
+	publicMessages.Store(code, message)
+}
+
+// WithPublic annotates err with a message that is safe to show to users
+// The public message does not change Error.
+// If err is nil, WithPublic returns nil.
+func WithPublic(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	return &withPublic{
+		error:  err,
+		public: message,
+	}
+}
+
+type withPublic struct {
+	error
+	public string
+}
+
+func (w *withPublic) errorOf() error {
+	return w.error
+}
+
+func (w *withPublic) Cause() error {
+	return w.error
+}
+
+func (w *withPublic) Unwrap() error {
+	return w.error
+}
+
+func (w *withPublic) Format(s fmt.State, verb rune) {
+	formatAnnotation(s, verb, w, w.error, "")
+}
+
+// PublicMessage returns the outermost public message of err
+// Without one, it returns the message registered for the latest code,
+// the http status text of the code's leading three digits if they are a 4xx or 5xx status,
+// or DefaultPublicMessage.
+// If err is nil, PublicMessage returns "".
+func PublicMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	public := ""
+	walk(err, func(err error) {
+		if e, ok := err.(*withPublic); ok && public == "" {
+			public = e.public
+		}
+	})
+	if public != "" {
+		return public
+	}
+	c := LatestCode(err)
+	if c == nil {
+		return DefaultPublicMessage
+	}
+	if message, ok := publicMessages.Load(c.Code()); ok {
+		return message.(string)
+	}
+	status := c.Code()
+	for status >= 1000 {
+		status /= 10
+	}
+	if text, ok := statusTexts[status]; ok {
+		return text
+	}
+	return DefaultPublicMessage
+}
+
+// statusTexts are the http status texts of 4xx and 5xx statuses, as net/http.StatusText
+var statusTexts = map[int]string{
+	400: "Bad Request",
+	401: "Unauthorized",
+	402: "Payment Required",
+	403: "Forbidden",
+	404: "Not Found",
+	405: "Method Not Allowed",
+	406: "Not Acceptable",
+	407: "Proxy Authentication Required",
+	408: "Request Timeout",
+	409: "Conflict",
+	410: "Gone",
+	411: "Length Required",
+	412: "Precondition Failed",
+	413: "Request Entity Too Large",
+	414: "Request URI Too Long",
+	415: "Unsupported Media Type",
+	416: "Requested Range Not Satisfiable",
+	417: "Expectation Failed",
+	418: "I'm a teapot",
+	421: "Misdirected Request",
+	422: "Unprocessable Entity",
+	423: "Locked",
+	424: "Failed Dependency",
+	425: "Too Early",
+	426: "Upgrade Required",
+	428: "Precondition Required",
+	429: "Too Many Requests",
+	431: "Request Header Fields Too Large",
+	451: "Unavailable For Legal Reasons",
+	500: "Internal Server Error",
+	501: "Not Implemented",
+	502: "Bad Gateway",
+	503: "Service Unavailable",
+	504: "Gateway Timeout",
+	505: "HTTP Version Not Supported",
+	506: "Variant Also Negotiates",
+	507: "Insufficient Storage",
+	508: "Loop Detected",
+	510: "Not Extended",
+	511: "Network Authentication Required",
+}
@@ -0,0 +1,111 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestPublicMessage(t *testing.T) {
+	RegisterPublicMessage(CodeOrderNotExists, "Order not found")
+	defer publicMessages.Delete(CodeOrderNotExists)
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			"nil",
+			nil,
+			"",
+		},
+		{
+			"no public message",
+			errors.New("dial tcp 10.0.0.1:3306: connection refused"),
+			DefaultPublicMessage,
+		},
+		{
+			"public",
+			WithPublic(errors.New("dial tcp 10.0.0.1:3306: connection refused"), "Order could not be placed"),
+			"Order could not be placed",
+		},
+		{
+			"outermost public",
+			WithPublic(Wrap(WithPublic(New("sql"), "inner"), "wrap"), "outer"),
+			"outer",
+		},
+		{
+			"wrapped public",
+			Wrap(WithPublic(ErrCodeUserNotFound, "User not found"), "wrap"),
+			"User not found",
+		},
+		{
+			"registered code",
+			ErrCodeOrderNotExists.Wrapf(New("sql"), "select"),
+			"Order not found",
+		},
+		{
+			"http status of code",
+			ErrCodeInvalidParams.WrapStack(New("sql")),
+			"Bad Request",
+		},
+		{
+			"unknown code",
+			NewWithCode(7, "internal"),
+			DefaultPublicMessage,
+		},
+		{
+			"2xx code",
+			NewWithCode(2001, "internal"),
+			DefaultPublicMessage,
+		},
+		{
+			"3xx code",
+			NewWithCode(3021, "internal"),
+			DefaultPublicMessage,
+		},
+		{
+			"1xx code",
+			NewWithCode(1001, "internal"),
+			DefaultPublicMessage,
+		},
+		{
+			"join",
+			Join(New("sql"), WithPublic(New("sql"), "Partially failed")),
+			"Partially failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PublicMessage(tt.err); got != tt.want {
+				t.Errorf("PublicMessage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithPublic(t *testing.T) {
+	if WithPublic(nil, "public") != nil {
+		t.Errorf("WithPublic() want nil")
+	}
+	err := WithPublic(ErrCodeUserNotFound, "User not found")
+	if err.Error() != ErrCodeUserNotFound.Error() {
+		t.Errorf("Error() = %v, want %v", err, ErrCodeUserNotFound)
+	}
+	if !Is(err, ErrCodeUserNotFound) || LatestCode(err).Code() != CodeUserNotFound {
+		t.Errorf("WithPublic() hides %v", ErrCodeUserNotFound)
+	}
+}
+
+func Test_statusTexts(t *testing.T) {
+	for status := 100; status < 600; status++ {
+		want := ""
+		if status >= 400 {
+			want = http.StatusText(status)
+		}
+		if got := statusTexts[status]; got != want {
+			t.Errorf("statusTexts[%d] = %q, want %q", status, got, want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultRecentSize is the number of errors kept for Recent
+const DefaultRecentSize = 100
+
+type recentEntry struct {
+	time time.Time
+	err  error
+}
+
+// recentRing keeps the last len(entries) observed errors
+type recentRing struct {
+	mu      sync.Mutex
+	entries []recentEntry
+	next    int
+	full    bool
+}
+
+var recent = &recentRing{entries: make([]recentEntry, DefaultRecentSize)}
+
+// SetRecentSize sets the number of errors kept for Recent and clears them
+// If n is not positive, observed errors are not kept.
+func SetRecentSize(n int) {
+	if n < 0 {
+		n = 0
+	}
+	recent.mu.Lock()
+	defer recent.mu.Unlock()
+	recent.entries = make([]recentEntry, n)
+	recent.next = 0
+	recent.full = false
+}
+
+func (r *recentRing) add(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.entries) == 0 {
+		return
+	}
+	r.entries[r.next] = recentEntry{time: time.Now(), err: err}
+	r.next++
+	if r.next == len(r.entries) {
+		r.next = 0
+		r.full = true
+	}
+}
+
+// list returns the kept errors, newest first
+func (r *recentRing) list() []recentEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := r.next
+	if r.full {
+		n = len(r.entries)
+	}
+	entries := make([]recentEntry, 0, n)
+	for i := 1; i <= n; i++ {
+		entries = append(entries, r.entries[(r.next-i+len(r.entries))%len(r.entries)])
+	}
+	return entries
+}
+
+// RecentError is an error kept by Observe
+type RecentError struct {
+	Time  time.Time `json:"time"`
+	Error string    `json:"error"`
+	// Detail is the error formatted with %+v
+	Detail string `json:"detail"`
+}
+
+// RecentGroup is a group of recent errors with the same fingerprint
+type RecentGroup struct {
+	Fingerprint string `json:"fingerprint"`
+	// Code is the latest code of the errors, or 0 if they have none
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	// Frame is the first frame of the innermost stack, the one Fingerprint uses, as "func file:line"
+	Frame    string        `json:"frame"`
+	Count    int           `json:"count"`
+	LastSeen time.Time     `json:"last_seen"`
+	Errors   []RecentError `json:"errors"`
+}
+
+// Recent groups the last observed errors by fingerprint, the most recently seen first
+func Recent() []RecentGroup {
+	var groups []RecentGroup
+	index := make(map[string]int)
+	for _, entry := range recent.list() {
+		key := Fingerprint(entry.err)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			group := RecentGroup{
+				Fingerprint: key,
+				Message:     entry.err.Error(),
+				LastSeen:    entry.time,
+			}
+			if c := LatestCode(entry.err); c != nil {
+				group.Code = c.Code()
+			}
+			if st := innerStackOf(entry.err); st != nil {
+				if frame, ok := st.frame(); ok {
+					group.Frame = shortFuncName(frame.Function) + " " + frame.File + ":" + strconv.Itoa(frame.Line)
+				}
+			}
+			groups = append(groups, group)
+		}
+		groups[i].Count++
+		groups[i].Errors = append(groups[i].Errors, RecentError{
+			Time:   entry.time,
+			Error:  entry.err.Error(),
+			Detail: fmt.Sprintf("%+v", entry.err),
+		})
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].LastSeen.After(groups[j].LastSeen)
+	})
+	return groups
+}
@@ -0,0 +1,40 @@
+package errors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecent(t *testing.T) {
+	SetRecentSize(3)
+	defer SetRecentSize(DefaultRecentSize)
+	for i := 0; i < 4; i++ {
+		Observe(ErrCodeUserNotFound.Wrapf(New("err"), "user %d", i))
+	}
+	Observe(New("other"))
+	groups := Recent()
+	if len(groups) != 2 {
+		t.Fatalf("Recent() = %v groups, want 2", len(groups))
+	}
+	if groups[0].Message != "other" || groups[0].Count != 1 || groups[0].Frame != "" {
+		t.Errorf("Recent()[0] = %+v, want other", groups[0])
+	}
+	if g := groups[1]; g.Code != CodeUserNotFound || g.Count != 2 || !strings.HasPrefix(g.Frame, "errors.TestRecent ") {
+		t.Errorf("Recent()[1] = %+v, want 2 x %v", g, CodeUserNotFound)
+	}
+	if got := groups[1].Errors[0].Error; !strings.Contains(got, "user 3") {
+		t.Errorf("Recent()[1].Errors[0] = %v, want newest first", got)
+	}
+
+	SetRecentSize(1)
+	Observe(WrapForce(fingerprintFrom("inner"), "outer"))
+	if groups := Recent(); len(groups) != 1 || !strings.HasPrefix(groups[0].Frame, "errors.fingerprintFrom ") {
+		t.Errorf("Recent() = %+v, want the innermost frame", groups)
+	}
+
+	SetRecentSize(0)
+	Observe(New("err"))
+	if groups := Recent(); len(groups) != 0 {
+		t.Errorf("Recent() = %v, want empty", groups)
+	}
+}
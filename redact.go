@@ -0,0 +1,136 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// RedactedMarker replaces redacted format arguments
+const RedactedMarker = "‹×›"
+
+// RedactPolicy decides which format arguments are redacted when messages are rendered
+type RedactPolicy int32
+
+const (
+	// RedactNone renders every argument, for local debugging
+	RedactNone RedactPolicy = iota
+	// RedactSensitive redacts the arguments marked by Sensitive
+	RedactSensitive
+	// RedactUnsafe redacts every argument that is not marked by Safe
+	RedactUnsafe
+)
+
+var redactPolicy = int32(RedactSensitive)
+
+// SetRedactPolicy sets the global redaction policy, the default is RedactSensitive
+func SetRedactPolicy(policy RedactPolicy) {
+	atomic.StoreInt32(&redactPolicy, int32(policy))
+}
+
+// Sensitive marks a format argument, such as an email or token, that must not appear in logs
+//
+//	errors.NewWithStack("user %s not found", errors.Sensitive(email))
+func Sensitive(v any) any {
+	return sensitiveArg{v}
+}
+
+// Safe marks a format argument that is never redacted
+func Safe(v any) any {
+	return safeArg{v}
+}
+
+type sensitiveArg struct {
+	value any
+}
+
+func (a sensitiveArg) Format(s fmt.State, verb rune) {
+	fmt.Fprintf(s, directive(s, verb), a.value)
+}
+
+type safeArg struct {
+	value any
+}
+
+func (a safeArg) Format(s fmt.State, verb rune) {
+	fmt.Fprintf(s, directive(s, verb), a.value)
+}
+
+type redactedArg struct{}
+
+func (redactedArg) Format(s fmt.State, verb rune) {
+	io.WriteString(s, RedactedMarker)
+}
+
+// directive rebuilds the formatting directive of verb with the flags, width and precision of s
+func directive(s fmt.State, verb rune) string {
+	var builder strings.Builder
+	builder.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if s.Flag(int(flag)) {
+			builder.WriteRune(flag)
+		}
+	}
+	if width, ok := s.Width(); ok {
+		builder.WriteString(strconv.Itoa(width))
+	}
+	if precision, ok := s.Precision(); ok {
+		builder.WriteByte('.')
+		builder.WriteString(strconv.Itoa(precision))
+	}
+	builder.WriteRune(verb)
+	return builder.String()
+}
+
+// formatMessage renders format and args, message is the literal message without format
+// If redacted is set, arguments are redacted by the redaction policy.
+func formatMessage(message, format string, args []any, redacted bool) string {
+	if format == "" {
+		return message
+	}
+	if redacted {
+		if r := redactArgs(args); r != nil {
+			return fmt.Sprintf(format, r...)
+		}
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// exportArgs returns a copy of args redacted by the redaction policy, for Args
+func exportArgs(args []any) []any {
+	if r := redactArgs(args); r != nil {
+		return r
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	return append([]any(nil), args...)
+}
+
+// redactArgs returns a copy of args redacted by the redaction policy, or nil if nothing is redacted
+func redactArgs(args []any) []any {
+	policy := RedactPolicy(atomic.LoadInt32(&redactPolicy))
+	if len(args) == 0 || policy == RedactNone {
+		return nil
+	}
+	var redacted []any
+	for i, arg := range args {
+		switch arg.(type) {
+		case safeArg:
+			continue
+		case sensitiveArg:
+		default:
+			if policy != RedactUnsafe {
+				continue
+			}
+		}
+		if redacted == nil {
+			redacted = make([]any, len(args))
+			copy(redacted, args)
+		}
+		redacted[i] = redactedArg{}
+	}
+	return redacted
+}
@@ -0,0 +1,99 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSetRedactPolicy(t *testing.T) {
+	err := Wrap(
+		NewWithCode(404, "user %s not found", Sensitive("a@b.com")),
+		"query %d in %s, token %5.2f", Safe(42), "orders", Sensitive(1.5),
+	)
+	tests := []struct {
+		name   string
+		policy RedactPolicy
+		want   string
+	}{
+		{
+			"none",
+			RedactNone,
+			"query 42 in orders, token  1.50 -> {[404: user a@b.com not found]}",
+		},
+		{
+			"sensitive",
+			RedactSensitive,
+			"query 42 in orders, token ‹×› -> {[404: user ‹×› not found]}",
+		},
+		{
+			"unsafe",
+			RedactUnsafe,
+			"query 42 in ‹×›, token ‹×› -> {[404: user ‹×› not found]}",
+		},
+	}
+	defer SetRedactPolicy(RedactSensitive)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetRedactPolicy(tt.policy)
+			if got := err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+			if got := fmt.Sprintf("%v", err); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSensitive(t *testing.T) {
+	defer SetRedactPolicy(RedactSensitive)
+	errCode := NewWithCode(100, "token %q", Sensitive("abc"))
+	err := errCode.Wrapf(New("go err"), "id: %03d", Sensitive(7))
+
+	SetRedactPolicy(RedactSensitive)
+	if got := LatestCode(err).Message(); got != "token ‹×›" {
+		t.Errorf("Message() = %q, want %q", got, "token ‹×›")
+	}
+	if got := err.Error(); got != "[100: token ‹×›] -> {id: ‹×› -> {go err}}" {
+		t.Errorf("Error() = %q", got)
+	}
+
+	SetRedactPolicy(RedactNone)
+	if got := err.Error(); got != `[100: token "abc"] -> {id: 007 -> {go err}}` {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestSensitive_Is(t *testing.T) {
+	err1 := NewWithMessage("user %s", Sensitive("a"))
+	err2 := NewWithMessage("user %s", Sensitive("b"))
+	if Is(err1, err2) {
+		t.Errorf("Is() = true, want false")
+	}
+	if !Is(Wrap(err1, "wrap"), NewWithMessage("user %s", Sensitive("a"))) {
+		t.Errorf("Is() = false, want true")
+	}
+}
+
+func TestSensitive_Args(t *testing.T) {
+	defer SetRedactPolicy(RedactSensitive)
+	err := Wrap(NewWithCode(100, "token %s", Sensitive("SECRET")), "user %d", 7)
+
+	SetRedactPolicy(RedactSensitive)
+	if got := fmt.Sprint(LatestCode(err).Args()...); got != "‹×›" {
+		t.Errorf("Args() = %q, want %q", got, "‹×›")
+	}
+	if got := fmt.Sprint(LatestMessage(err).Args()...); got != "7" {
+		t.Errorf("Args() = %q, want %q", got, "7")
+	}
+	args := LatestMessage(err).Args()
+	args[0] = 8
+	if got := LatestMessage(err).Message(); got != "user 7" {
+		t.Errorf("Args() shares its slice, Message() = %q", got)
+	}
+
+	SetRedactPolicy(RedactNone)
+	if got := fmt.Sprint(LatestCode(err).Args()...); got != "SECRET" {
+		t.Errorf("Args() = %q, want %q", got, "SECRET")
+	}
+}
@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WithSecondary annotates primary with a secondary error, such as a failed rollback,
+// that is kept for diagnostics but takes no part in Is, As, LatestCode and Error
+// The secondary error appears in %+v and Tree.
+// If primary is nil, WithSecondary returns nil; if secondary is nil, it returns primary.
+func WithSecondary(primary, secondary error) error {
+	if primary == nil {
+		return nil
+	}
+	if secondary == nil {
+		return primary
+	}
+	return &withSecondary{
+		error:     primary,
+		secondary: secondary,
+	}
+}
+
+// SecondaryErrors returns the secondary errors in the chain and joins of err, outermost first
+func SecondaryErrors(err error) []error {
+	var errs []error
+	walk(err, func(err error) {
+		if e, ok := err.(*withSecondary); ok {
+			errs = append(errs, e.secondary)
+		}
+	})
+	return errs
+}
+
+type withSecondary struct {
+	error
+	secondary error
+}
+
+func (w *withSecondary) errorOf() error {
+	return w.error
+}
+
+func (w *withSecondary) Cause() error {
+	return w.error
+}
+
+func (w *withSecondary) Unwrap() error {
+	return w.error
+}
+
+func (w *withSecondary) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			if causedByStyle() {
+				io.WriteString(s, causedBy(w))
+				return
+			}
+			fmt.Fprintf(s, "%+v\n", w.error)
+			secondary := strings.TrimSuffix(fmt.Sprintf("%+v", w.secondary), "\n")
+			io.WriteString(s, "secondary error: ")
+			io.WriteString(s, strings.ReplaceAll(secondary, "\n", "\n\t"))
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, w.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", w.Error())
+	}
+}
@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWithSecondary(t *testing.T) {
+	errRollback := NewWithCode(500100000, "rollback failed")
+	primary := ErrCodeUserNotFound.WrapStack(errors.New("record not found"))
+	err := Wrap(WithSecondary(primary, errRollback), "create order")
+
+	if got, want := err.Error(), "create order -> {[500201010: user not found] -> {record not found}}"; got != want {
+		t.Errorf("Error() = %v, want %v", got, want)
+	}
+	if !Is(err, ErrCodeUserNotFound) {
+		t.Errorf("Is() = false, want true for primary")
+	}
+	if Is(err, errRollback) {
+		t.Errorf("Is() = true, want false for secondary")
+	}
+	if got := LatestCode(err).Code(); got != CodeUserNotFound {
+		t.Errorf("LatestCode() = %v, want %v", got, CodeUserNotFound)
+	}
+	if got := CodesIn(err); len(got) != 1 {
+		t.Errorf("CodesIn() = %v, want [%v]", got, CodeUserNotFound)
+	}
+	if got := SecondaryErrors(err); len(got) != 1 || got[0] != errRollback {
+		t.Errorf("SecondaryErrors() = %v, want [%v]", got, errRollback)
+	}
+	str := fmt.Sprintf("%+v", err)
+	if !strings.Contains(str, "\nsecondary error: 500100000: rollback failed\ncreate order") {
+		t.Errorf("Format() = %v", str)
+	}
+}
+
+func TestWithSecondary_nil(t *testing.T) {
+	err := New("err")
+	if WithSecondary(nil, nil) != nil {
+		t.Errorf("WithSecondary() want nil")
+	}
+	if WithSecondary(err, nil) != err {
+		t.Errorf("WithSecondary() want %v", err)
+	}
+	if got := WithSecondary(nil, ErrCodeUserNotFound); got != nil || Is(got, ErrCodeUserNotFound) {
+		t.Errorf("WithSecondary() = %v, want nil", got)
+	}
+}
+
+func TestWithSecondary_causedBy(t *testing.T) {
+	SetStackStyle(StackStyleCausedBy)
+	defer SetStackStyle(StackStyleFull)
+
+	err := WithSecondary(traceInner(), NewWithStack("rollback failed"))
+	got := fmt.Sprintf("%+v", err)
+	if !strings.HasPrefix(got, "err1 -> {go err}\n\tat github.com/ace-zhaoy/errors.traceInner(") ||
+		!strings.Contains(got, "\nsecondary error: rollback failed\n\t\tat github.com/ace-zhaoy/errors.TestWithSecondary_causedBy(") {
+		t.Errorf("Format() = %s", got)
+	}
+}
@@ -0,0 +1,103 @@
+package errors
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// SeverityLevel is the severity of an error, a higher level is more severe
+type SeverityLevel int
+
+const (
+	SeverityUnset SeverityLevel = iota
+	SeverityDebug
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+var severityNames = [...]string{
+	SeverityUnset:    "unset",
+	SeverityDebug:    "debug",
+	SeverityInfo:     "info",
+	SeverityWarning:  "warning",
+	SeverityError:    "error",
+	SeverityCritical: "critical",
+}
+
+func (l SeverityLevel) String() string {
+	if l >= 0 && int(l) < len(severityNames) {
+		return severityNames[l]
+	}
+	return "severity(" + strconv.Itoa(int(l)) + ")"
+}
+
+var codeSeverities sync.Map
+
+// SetCodeSeverity sets the severity of errors with code in their chain
+func SetCodeSeverity(code int, level SeverityLevel) {
+	codeSeverities.Store(code, level)
+}
+
+// WithSeverity annotates err with level, the severity does not change Error
+// If err is nil, WithSeverity returns nil.
+func WithSeverity(err error, level SeverityLevel) error {
+	if err == nil {
+		return nil
+	}
+	return &withSeverity{
+		error: err,
+		level: level,
+	}
+}
+
+type withSeverity struct {
+	error
+	level SeverityLevel
+}
+
+func (w *withSeverity) errorOf() error {
+	return w.error
+}
+
+func (w *withSeverity) Cause() error {
+	return w.error
+}
+
+func (w *withSeverity) Unwrap() error {
+	return w.error
+}
+
+func (w *withSeverity) Format(s fmt.State, verb rune) {
+	formatAnnotation(s, verb, w, w.error, "")
+}
+
+// Severity returns the highest severity annotated or registered for a code in the chain and joins of err
+// Without one, it returns SeverityError.
+// If err is nil, Severity returns SeverityUnset.
+func Severity(err error) SeverityLevel {
+	if err == nil {
+		return SeverityUnset
+	}
+	level := SeverityUnset
+	walk(err, func(err error) {
+		l := SeverityUnset
+		switch e := err.(type) {
+		case *withSeverity:
+			l = e.level
+		case ErrorCode:
+			if v, ok := codeSeverities.Load(e.Code()); ok {
+				l = v.(SeverityLevel)
+			}
+		}
+		if l > level {
+			level = l
+		}
+	})
+	if level == SeverityUnset {
+		return SeverityError
+	}
+	return level
+}
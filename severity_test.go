@@ -0,0 +1,59 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSeverity(t *testing.T) {
+	errCritical := NewWithCode(500999001, "disk full")
+	errWarning := NewWithCode(400999001, "rate limited")
+	SetCodeSeverity(errCritical.Code(), SeverityCritical)
+	SetCodeSeverity(errWarning.Code(), SeverityWarning)
+	tests := []struct {
+		name string
+		err  error
+		want SeverityLevel
+	}{
+		{"nil", nil, SeverityUnset},
+		{"default", errors.New("go err"), SeverityError},
+		{"code", Wrap(errWarning, "wrap"), SeverityWarning},
+		{"annotated", WithSeverity(New("err"), SeverityInfo), SeverityInfo},
+		{"escalated", WithSeverity(errCritical, SeverityDebug), SeverityCritical},
+		{"chain max", WithSeverity(Wrap(errWarning, "wrap"), SeverityError), SeverityError},
+		{"join max", Join(errWarning, Wrap(errCritical, "wrap")), SeverityCritical},
+		{"secondary", WithSecondary(errWarning, errCritical), SeverityWarning},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Severity(tt.err); got != tt.want {
+				t.Errorf("Severity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithSeverity(t *testing.T) {
+	if WithSeverity(nil, SeverityCritical) != nil {
+		t.Errorf("WithSeverity() want nil")
+	}
+	err := WithSeverity(ErrCodeUserNotFound, SeverityWarning)
+	if err.Error() != ErrCodeUserNotFound.Error() {
+		t.Errorf("Error() = %v, want %v", err.Error(), ErrCodeUserNotFound.Error())
+	}
+	if !Is(err, ErrCodeUserNotFound) {
+		t.Errorf("Is() = false, want true")
+	}
+	if got := SeverityLevel(9).String(); got != "severity(9)" {
+		t.Errorf("String() = %v, want %v", got, "severity(9)")
+	}
+}
+
+func TestLatestCode_joinSeverity(t *testing.T) {
+	defer SetJoinCodePolicy(JoinCodeFirst)
+	SetJoinCodePolicy(JoinCodeHighest)
+	err := Join(ErrCodeUserNotFound, WithSeverity(ErrCodeInvalidParams, SeverityCritical))
+	if got := LatestCode(err); got == nil || got.Code() != CodeInvalidParams {
+		t.Errorf("LatestCode() = %v, want %v", got, CodeInvalidParams)
+	}
+}
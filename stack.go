@@ -5,6 +5,7 @@ import (
 	"io"
 	"runtime"
 	"strconv"
+	"strings"
 )
 
 type stack []uintptr
@@ -40,3 +41,67 @@ func callers() *stack {
 	var st stack = pcs[0:n]
 	return &st
 }
+
+// frame returns the first frame outside the runtime package
+func (s *stack) frame() (runtime.Frame, bool) {
+	frames := runtime.CallersFrames(*s)
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" && !strings.HasPrefix(frame.Function, "runtime.") {
+			return frame, true
+		}
+		if !more {
+			return frame, false
+		}
+	}
+}
+
+// funcName returns the name of the first frame outside the runtime package,
+// trimmed of its import path
+func (s *stack) funcName() string {
+	frame, ok := s.frame()
+	if !ok {
+		return "unknown"
+	}
+	return shortFuncName(frame.Function)
+}
+
+func shortFuncName(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+// key returns a string that is equal for stacks with the same program counters
+func (s *stack) key() string {
+	var builder strings.Builder
+	for _, pc := range *s {
+		builder.WriteString(strconv.FormatUint(uint64(pc), 16))
+		builder.WriteByte(' ')
+	}
+	return builder.String()
+}
+
+// stackOf returns the outermost stack of err, or nil if there is none
+func stackOf(err error) *stack {
+	for err != nil {
+		if e, ok := err.(*withStack); ok && e.stack != nil {
+			return e.stack
+		}
+		e, ok := err.(causer)
+		if !ok {
+			break
+		}
+		err = e.Cause()
+	}
+	return nil
+}
+
+// innerStackOf returns the innermost stack in the chain and joins of err, where the error was captured
+func innerStackOf(err error) *stack {
+	var st *stack
+	walk(err, func(err error) {
+		if e, ok := err.(*withStack); ok && e.stack != nil {
+			st = e.stack
+		}
+	})
+	return st
+}
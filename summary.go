@@ -0,0 +1,90 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SummaryGroup is a group of joined errors with the same code, or the same message template if there is no code
+type SummaryGroup struct {
+	// Code is 0 if the group is by message
+	Code    int
+	Message string
+	Count   int
+	Errors  []error
+}
+
+func (g SummaryGroup) String() string {
+	if g.Code != 0 {
+		return fmt.Sprintf("%d x [%d: %s]", g.Count, g.Code, g.Message)
+	}
+	return fmt.Sprintf("%d x %s", g.Count, g.Message)
+}
+
+// Summary groups the joined errors by code or message, ordered by count
+func (w *withJoin) Summary() []SummaryGroup {
+	return summaryGroups(w.snapshot())
+}
+
+func summaryGroups(errs []error) []SummaryGroup {
+	var groups []SummaryGroup
+	index := make(map[string]int)
+	for _, err := range errs {
+		code, message := summaryKey(err)
+		key := strconv.Itoa(code) + ":" + message
+		if code != 0 {
+			key = strconv.Itoa(code)
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, SummaryGroup{
+				Code:    code,
+				Message: message,
+			})
+		}
+		groups[i].Count++
+		groups[i].Errors = append(groups[i].Errors, err)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Count > groups[j].Count
+	})
+	return groups
+}
+
+func summaryKey(err error) (int, string) {
+	if c := LatestCode(err); c != nil {
+		return c.Code(), c.Message()
+	}
+	if m := LatestMessage(err); m != nil {
+		return 0, m.Template()
+	}
+	return 0, err.Error()
+}
+
+// summarize renders at most maxEntries groups, followed by the first error of each group in full if plus is set
+func summarize(errs []error, maxEntries int, plus bool) string {
+	groups := summaryGroups(errs)
+	var builder strings.Builder
+	more := 0
+	for i, group := range groups {
+		if i >= maxEntries {
+			more += group.Count
+			continue
+		}
+		if i > 0 {
+			builder.WriteByte('\n')
+		}
+		builder.WriteString(group.String())
+		if plus {
+			fmt.Fprintf(&builder, "\n%+v", group.Errors[0])
+		}
+	}
+	if more > 0 {
+		fmt.Fprintf(&builder, "\nand %d more", more)
+	}
+	return builder.String()
+}
@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func newSummaryJoin(maxEntries int) ErrorJoin {
+	jerr := NewWithJoinOptions(JoinSummary(maxEntries))
+	for i := 0; i < 5; i++ {
+		jerr.Append(ErrCodeUserNotFound.Wrapf(errors.New("record not found"), "id: %d", i))
+	}
+	for i := 0; i < 3; i++ {
+		jerr.Append(Wrap(errors.New("timeout"), "query %d", i))
+	}
+	jerr.Append(errors.New("go err"))
+	return jerr
+}
+
+func Test_withJoin_Summary(t *testing.T) {
+	groups := newSummaryJoin(10).Summary()
+	want := []SummaryGroup{
+		{Code: CodeUserNotFound, Message: "user not found", Count: 5},
+		{Message: "query %d", Count: 3},
+		{Message: "go err", Count: 1},
+	}
+	if len(groups) != len(want) {
+		t.Fatalf("Summary() = %v, want %v", groups, want)
+	}
+	for i, g := range groups {
+		if g.Code != want[i].Code || g.Message != want[i].Message || g.Count != want[i].Count || len(g.Errors) != g.Count {
+			t.Errorf("Summary()[%d] = %v, want %v", i, g, want[i])
+		}
+	}
+}
+
+func Test_withJoin_summaryFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxEntries int
+		format     string
+		want       string
+	}{
+		{
+			"all",
+			10,
+			"%v",
+			"5 x [500201010: user not found]\n3 x query %d\n1 x go err",
+		},
+		{
+			"capped",
+			1,
+			"%s",
+			"5 x [500201010: user not found]\nand 4 more",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jerr := newSummaryJoin(tt.maxEntries)
+			if got := fmt.Sprintf(tt.format, jerr); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+			if got := jerr.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	str := fmt.Sprintf("%+v", newSummaryJoin(1))
+	if strings.Count(str, "Test_withJoin_summaryFormat") != 1 {
+		t.Errorf("Format() %%+v = %v, want one stack", str)
+	}
+	if !strings.HasPrefix(str, "5 x [500201010: user not found]\nrecord not found\nid: 0\n") || !strings.HasSuffix(str, "\nand 4 more\n") {
+		t.Errorf("Format() %%+v = %v", str)
+	}
+}
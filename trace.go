@@ -0,0 +1,129 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// StackStyle decides how %+v renders errors with stacks
+type StackStyle int32
+
+const (
+	// StackStyleFull prints every message and full stack, innermost first
+	StackStyleFull StackStyle = iota
+	// StackStyleCausedBy prints the outer stack, then each inner one as "Caused by: ...",
+	// collapsing frames shared with the enclosing stack into "... N more"
+	StackStyleCausedBy
+)
+
+var stackStyle int32
+
+// SetStackStyle sets the style of %+v, the default is StackStyleFull
+func SetStackStyle(style StackStyle) {
+	atomic.StoreInt32(&stackStyle, int32(style))
+}
+
+func causedByStyle() bool {
+	return StackStyle(atomic.LoadInt32(&stackStyle)) == StackStyleCausedBy
+}
+
+// causedBy renders err like a Java stack trace
+//
+//	err2 -> {err1 -> {go err}}
+//		at main.B(/go/src/main.go:20)
+//		at main.main(/go/src/main.go:25)
+//		... 2 more
+//	Caused by: err1 -> {go err}
+//		at main.A(/go/src/main.go:11)
+//		... 4 more
+func causedBy(err error) string {
+	var builder strings.Builder
+	builder.WriteString(err.Error())
+	writeTraces(&builder, err, nil, true)
+	return builder.String()
+}
+
+// writeTraces writes the stacks in the chain of err, every stack but the first is headed by "Caused by"
+// Hints, details and secondary errors are written after the stacks of the errors they annotate, as in StackStyleFull.
+// If headed is set, the first stack belongs to the header already written.
+func writeTraces(builder *strings.Builder, err error, enclosing *stack, headed bool) {
+	for err != nil {
+		if e, ok := err.(*withStack); ok && e.stack != nil {
+			if !headed {
+				builder.WriteString("\nCaused by: ")
+				builder.WriteString(e.error.Error())
+			}
+			writeFrames(builder, e.stack, enclosing)
+			enclosing = e.stack
+			headed = false
+			err = e.error
+			continue
+		}
+
+		if e, ok := err.(*withOpaque); ok {
+			if e.visible != nil {
+				// the hidden chain of a Boundary is its cause, not part of the visible error
+				builder.WriteString("\nCaused by: ")
+				builder.WriteString(e.hidden.Error())
+				headed = true
+			}
+			err = e.hidden
+			continue
+		}
+
+		switch e := err.(type) {
+		case *withHint:
+			writeTraces(builder, e.error, enclosing, headed)
+			builder.WriteString("\nhint: " + e.hint)
+			return
+		case *withDetail:
+			writeTraces(builder, e.error, enclosing, headed)
+			builder.WriteString("\ndetail: " + e.detail)
+			return
+		case *withSecondary:
+			writeTraces(builder, e.error, enclosing, headed)
+			builder.WriteString("\nsecondary error: ")
+			builder.WriteString(strings.ReplaceAll(causedBy(e.secondary), "\n", "\n\t"))
+			return
+		}
+
+		e, ok := err.(causer)
+		if !ok {
+			if j, ok := err.(joiner); ok {
+				for _, err := range j.Unwrap() {
+					builder.WriteString("\nCaused by: ")
+					builder.WriteString(err.Error())
+					writeTraces(builder, err, enclosing, true)
+				}
+			}
+			return
+		}
+		err = e.Cause()
+	}
+}
+
+// writeFrames writes the frames of st, frames shared with the end of enclosing are collapsed
+func writeFrames(builder *strings.Builder, st, enclosing *stack) {
+	frames := *st
+	common := 0
+	if enclosing != nil {
+		for i, j := len(frames)-1, len(*enclosing)-1; i >= 0 && j >= 0 && frames[i] == (*enclosing)[j]; i, j = i-1, j-1 {
+			common++
+		}
+	}
+	for _, pc := range frames[:len(frames)-common] {
+		pc = pc - 1
+		fn := runtime.FuncForPC(pc)
+		name, line, file := "unknown", 0, "unknown"
+		if fn != nil {
+			name = fn.Name()
+			file, line = fn.FileLine(pc)
+		}
+		fmt.Fprintf(builder, "\n\tat %s(%s:%d)", name, file, line)
+	}
+	if common > 0 {
+		fmt.Fprintf(builder, "\n\t... %d more", common)
+	}
+}
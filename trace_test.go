@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func traceInner() error {
+	return WrapForce(errors.New("go err"), "err1")
+}
+
+func traceOuter() error {
+	return WrapForce(traceInner(), "err2")
+}
+
+func TestSetStackStyle(t *testing.T) {
+	SetStackStyle(StackStyleCausedBy)
+	defer SetStackStyle(StackStyleFull)
+
+	err := traceOuter()
+	got := fmt.Sprintf("%+v", err)
+	lines := strings.Split(got, "\n")
+	if lines[0] != "err2 -> {err1 -> {go err}}" {
+		t.Errorf("Format() header = %q, want %q", lines[0], "err2 -> {err1 -> {go err}}")
+	}
+	causedBy := regexp.MustCompile(`(?s)` +
+		`\n\tat github\.com/ace-zhaoy/errors\.traceOuter\([^)]+trace_test\.go:\d+\)` +
+		`\n\tat github\.com/ace-zhaoy/errors\.TestSetStackStyle\(.*` +
+		`\nCaused by: err1 -> \{go err\}` +
+		`\n\tat github\.com/ace-zhaoy/errors\.traceInner\([^)]+trace_test\.go:\d+\)` +
+		`\n\tat github\.com/ace-zhaoy/errors\.traceOuter\([^)]+trace_test\.go:\d+\)` +
+		`\n\t\.\.\. \d+ more$`)
+	if !causedBy.MatchString(got) {
+		t.Errorf("Format() = %s", got)
+	}
+	if strings.Count(got, "TestSetStackStyle") != 1 {
+		t.Errorf("Format() common frames are not collapsed: %s", got)
+	}
+
+	join := Join(traceInner(), New("err3"))
+	got = fmt.Sprintf("%+v", join)
+	if !strings.HasPrefix(got, "err1 -> {go err}\nerr3\nCaused by: err1 -> {go err}\n\tat github.com/ace-zhaoy/errors.traceInner(") ||
+		!strings.HasSuffix(got, "\nCaused by: err3") {
+		t.Errorf("Format() join = %s", got)
+	}
+}
+
+func TestSetStackStyle_full(t *testing.T) {
+	err := traceOuter()
+	got := fmt.Sprintf("%+v", err)
+	if strings.Contains(got, "Caused by") || strings.Count(got, "TestSetStackStyle_full") != 2 {
+		t.Errorf("Format() = %s", got)
+	}
+}
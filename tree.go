@@ -0,0 +1,149 @@
+package errors
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	colorReset  = "\x1b[0m"
+	colorFaint  = "\x1b[2m"
+	colorYellow = "\x1b[33m"
+	colorCyan   = "\x1b[36m"
+)
+
+// TreeOption configures Tree
+type TreeOption func(*treePrinter)
+
+// TreeColor colors codes, joins and stack locations with ANSI escapes for terminals
+func TreeColor() TreeOption {
+	return func(t *treePrinter) {
+		t.color = true
+	}
+}
+
+// Tree renders err as an indented tree, one line per wrapper with its stack location
+// and one branch per joined error
+//
+//	service err
+//	└── [400102030] invalid params
+//	    └── [500201010] user not found (main.SqlFirst main.go:27)
+//	        └── param: id: 1
+//	            └── record not found
+func Tree(err error, opts ...TreeOption) string {
+	if err == nil {
+		return ""
+	}
+	t := &treePrinter{}
+	for _, opt := range opts {
+		opt(t)
+	}
+	t.write(err, "", "")
+	return strings.TrimSuffix(t.builder.String(), "\n")
+}
+
+type treePrinter struct {
+	builder strings.Builder
+	color   bool
+}
+
+func (t *treePrinter) write(err error, prefix, indent string) {
+	var st *stack
+layers:
+	for {
+		switch e := err.(type) {
+		case *withStack:
+			if st == nil {
+				st = e.stack
+			}
+			err = e.error
+		case *withFormatter:
+			err = e.error
+		default:
+			break layers
+		}
+	}
+
+	label, children := t.label(err)
+	t.builder.WriteString(prefix)
+	t.builder.WriteString(label)
+	if st != nil {
+		if frame, ok := st.frame(); ok {
+			location := fmt.Sprintf(" (%s %s:%d)", shortFuncName(frame.Function), filepath.Base(frame.File), frame.Line)
+			t.builder.WriteString(t.paint(colorFaint, location))
+		}
+	}
+	t.builder.WriteByte('\n')
+
+	for i, child := range children {
+		branch, next := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, next = "└── ", "    "
+		}
+		t.write(child, indent+branch, indent+next)
+	}
+}
+
+// label returns the line for err and the errors it wraps
+func (t *treePrinter) label(err error) (string, []error) {
+	switch e := err.(type) {
+	case *withMessage:
+		return e.Message(), causes(e.cause)
+	case *withCode:
+		return t.paint(colorYellow, fmt.Sprintf("[%d]", e.code)) + " " + e.Message(), causes(e.cause)
+	case *withItem:
+		return fmt.Sprintf("item[%v]", e.key), causes(e.cause)
+	case *withPublic:
+		return fmt.Sprintf("public: %q", e.public), causes(e.error)
+	case *withSeverity:
+		return "severity: " + e.level.String(), causes(e.error)
+	case *withHint:
+		return "hint: " + e.hint, causes(e.error)
+	case *withDetail:
+		return "detail: " + e.detail, causes(e.error)
+	case *withOpaque:
+		if e.visible == nil {
+			return "opaque", causes(e.hidden)
+		}
+		return "boundary " + e.visible.Error(), causes(e.hidden)
+	case *withSecondary:
+		return "secondary: " + e.secondary.Error(), causes(e.error)
+	case joiner:
+		errs := e.Unwrap()
+		return t.paint(colorCyan, fmt.Sprintf("join (%d)", len(errs))), errs
+	case causer:
+		return wrapperLabel(err, e.Cause()), causes(e.Cause())
+	case interface{ Unwrap() error }:
+		return wrapperLabel(err, e.Unwrap()), causes(e.Unwrap())
+	}
+	return err.Error(), nil
+}
+
+// wrapperLabel returns the text err adds to cause, such as "ctx" for fmt.Errorf("ctx: %w", cause),
+// or the type of err if it adds none
+func wrapperLabel(err, cause error) string {
+	text := err.Error()
+	if cause != nil {
+		text = strings.TrimSuffix(text, cause.Error())
+		text = strings.TrimRight(text, ": ")
+	}
+	if text == "" {
+		return fmt.Sprintf("%T", err)
+	}
+	return text
+}
+
+func (t *treePrinter) paint(color, s string) string {
+	if !t.color {
+		return s
+	}
+	return color + s + colorReset
+}
+
+func causes(err error) []error {
+	if err == nil {
+		return nil
+	}
+	return []error{err}
+}
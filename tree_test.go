@@ -0,0 +1,97 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func treeSqlFirst() error {
+	return ErrCodeUserNotFound.Wrapf(errors.New("record not found"), "param: id: %d", 1)
+}
+
+func TestTree(t *testing.T) {
+	line := regexp.MustCompile(`tree_test\.go:\d+`)
+	tests := []struct {
+		name string
+		err  error
+		opts []TreeOption
+		want string
+	}{
+		{
+			"nil",
+			nil,
+			nil,
+			"",
+		},
+		{
+			"go err",
+			errors.New("go err"),
+			nil,
+			"go err",
+		},
+		{
+			"chain",
+			Wrap(ErrCodeInvalidParams.WrapStack(treeSqlFirst()), "service err"),
+			nil,
+			"service err\n" +
+				"└── [400102030] invalid params\n" +
+				"    └── [500201010] user not found (errors.treeSqlFirst tree_test.go:N)\n" +
+				"        └── param: id: 1\n" +
+				"            └── record not found",
+		},
+		{
+			"join",
+			Wrap(Join(ErrCodeUserNotFound, JoinIndexed(nil, New("err1")), New("err2")), "batch"),
+			nil,
+			"batch (errors.TestTree tree_test.go:N)\n" +
+				"└── join (3)\n" +
+				"    ├── [500201010] user not found\n" +
+				"    ├── join (1)\n" +
+				"    │   └── item[1]\n" +
+				"    │       └── err1\n" +
+				"    └── err2",
+		},
+		{
+			"public",
+			WithPublic(New("sql"), "Order could not be placed"),
+			nil,
+			"public: \"Order could not be placed\"\n" +
+				"└── sql",
+		},
+		{
+			"foreign wrappers",
+			Wrap(fmt.Errorf("ctx: %w", &os.PathError{Op: "open", Path: "a.txt", Err: os.ErrNotExist}), "load"),
+			nil,
+			"load (errors.TestTree tree_test.go:N)\n" +
+				"└── ctx\n" +
+				"    └── open a.txt\n" +
+				"        └── file does not exist",
+		},
+		{
+			"foreign wrapper without text",
+			fmt.Errorf("%w", errors.New("go err")),
+			nil,
+			"*fmt.wrapError\n" +
+				"└── go err",
+		},
+		{
+			"color",
+			Join(ErrCodeUserNotFound, New("err1")),
+			[]TreeOption{TreeColor()},
+			"\x1b[36mjoin (2)\x1b[0m\n" +
+				"├── \x1b[33m[500201010]\x1b[0m user not found\n" +
+				"└── err1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := line.ReplaceAllString(Tree(tt.err, tt.opts...), "tree_test.go:N")
+			if got != tt.want {
+				t.Errorf("Tree() = \n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}